@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/tyler180/nfl-data-go/pkg/nflreadgo"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "prefetch" {
+		os.Exit(runPrefetch(os.Args[2:]))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
 
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/pkg/nflreadgo"
+)
+
+const prefetchUsage = `usage: nflreadgo prefetch [flags] dataset...
+
+Downloads the given datasets into the cache (NFLREADGO_CACHE / -cache-dir)
+so later loads don't wait on the network, and prints what was already
+fresh, revalidated (304) or downloaded. Exits 1 if any asset failed.
+
+Datasets: pbp, playerstats_week, teamstats_week, schedules, rosters,
+rosters_weekly, snapcounts, injuries, depth_charts, players
+
+Flags:
+`
+
+// runPrefetch implements "nflreadgo prefetch" and returns the exit code.
+func runPrefetch(args []string) int {
+	fl := flag.NewFlagSet("prefetch", flag.ContinueOnError)
+	fl.Usage = func() {
+		fmt.Fprint(fl.Output(), prefetchUsage)
+		fl.PrintDefaults()
+	}
+	seasons := fl.String("seasons", "", `seasons: "2024", "2022,2024", "2020-2024" or "all" (default: current season)`)
+	workers := fl.Int("workers", 0, "downloads in flight (default: NFLREADGO_WORKERS or 4)")
+	dir := fl.String("cache-dir", "", "filesystem cache directory (default: NFLREADGO_CACHE_DIR)")
+	ttl := fl.Duration("ttl", 24*time.Hour, "cache TTL with -cache-dir")
+	timeout := fl.Duration("timeout", 10*time.Minute, "overall deadline")
+	if err := fl.Parse(args); err != nil {
+		return 2
+	}
+	if fl.NArg() == 0 {
+		fl.Usage()
+		return 2
+	}
+	sel, err := parseSeasons(*seasons)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "nflreadgo prefetch:", err)
+		return 2
+	}
+
+	var opts []nflreadgo.Option
+	if *workers > 0 {
+		opts = append(opts, nflreadgo.WithWorkers(*workers))
+	}
+	if *dir != "" {
+		opts = append(opts, nflreadgo.WithCache(nflreadgo.CacheFS, *dir, *ttl))
+	}
+	if sel == nil {
+		sel = nflreadgo.GetCurrentSeason(opts...)
+	}
+	ds := make([]nflreadgo.Dataset, fl.NArg())
+	for i, a := range fl.Args() {
+		ds[i] = nflreadgo.Dataset(a)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	results, err := nflreadgo.Prefetch(ctx, ds, sel, opts...)
+	if results == nil && err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	counts := map[string]int{}
+	for _, r := range results {
+		status := r.Status.String()
+		if r.Err != nil {
+			status = "failed"
+		}
+		counts[status]++
+		season := "all"
+		if r.Season > 0 {
+			season = strconv.Itoa(r.Season)
+		}
+		detail := r.URL
+		if r.Err != nil {
+			detail = r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s/%s\t%s\t%s\n", status, r.Dataset, season, formatBytes(r.Bytes), detail)
+	}
+	tw.Flush()
+	fmt.Printf("%d assets: %d fresh, %d revalidated, %d downloaded",
+		len(results), counts["fresh"], counts["revalidated"], counts["downloaded"])
+	if n := counts["stale"]; n > 0 {
+		fmt.Printf(", %d stale", n)
+	}
+	fmt.Printf(", %d failed\n", counts["failed"])
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// parseSeasons turns the -seasons flag into a loader selector: nil (flag
+// unset), true ("all"), or a []int from a comma list of years and ranges.
+func parseSeasons(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "":
+		return nil, nil
+	case "all":
+		return true, nil
+	}
+	var out []int
+	for _, part := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, fmt.Errorf("bad season %q", part)
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(hi); err != nil || to < from {
+				return nil, fmt.Errorf("bad season range %q", part)
+			}
+		}
+		for yr := from; yr <= to; yr++ {
+			out = append(out, yr)
+		}
+	}
+	return out, nil
+}
+
+// formatBytes renders n with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -14,6 +14,7 @@ import (
 	dchartpkg "github.com/tyler180/nfl-data-go/internal/datasets/depthcharts"
 	ffpidpkg "github.com/tyler180/nfl-data-go/internal/datasets/ffplayerids"
 	injpkg "github.com/tyler180/nfl-data-go/internal/datasets/injuries"
+	pbppkg "github.com/tyler180/nfl-data-go/internal/datasets/pbp"
 	playerpkg "github.com/tyler180/nfl-data-go/internal/datasets/players"
 	pstatpkg "github.com/tyler180/nfl-data-go/internal/datasets/playerstats"
 	rosterpkg "github.com/tyler180/nfl-data-go/internal/datasets/rosters"
@@ -24,9 +25,9 @@ import (
 
 func main() {
 	var (
-		dataset    = flag.String("dataset", "players", "dataset: players|snapcounts|playerstats|rosters|rosters_weekly|teamstats|depth_charts|injuries|ff_playerids")
+		dataset    = flag.String("dataset", "players", "dataset: players|snapcounts|playerstats|rosters|rosters_weekly|teamstats|depth_charts|injuries|ff_playerids|pbp")
 		limit      = flag.Int("limit", 3, "how many rows to print")
-		format     = flag.String("format", "", "prefer format: parquet|csv (optional)")
+		format     = flag.String("format", "", "prefer format: parquet|csv|csv.gz (optional)")
 		verbose    = flag.Bool("v", true, "verbose HTTP/caching logs")
 		season     = flag.Int("season", 0, "download a specific season file when available (e.g., 2023). 0 = all seasons (if available)")
 		week       = flag.Int("week", 0, "filter to a specific week (1-22). 0 = no filter")
@@ -37,11 +38,12 @@ func main() {
 	ctx := context.Background()
 	// Configure the library at runtime
 	opts := []configpkg.ConfigOption{configpkg.WithVerbose(*verbose)}
-	switch strings.ToLower(*format) {
-	case "csv":
-		opts = append(opts, configpkg.WithPreferFormat(downloadpkg.FormatCSV))
-	case "parquet":
-		opts = append(opts, configpkg.WithPreferFormat(downloadpkg.FormatParquet))
+	if *format != "" {
+		f, err := downloadpkg.ParseFormat(*format)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, configpkg.WithPreferFormat(f))
 	}
 	configpkg.UpdateConfig(opts...)
 
@@ -170,9 +172,9 @@ func main() {
 			err  error
 		)
 		if *season != 0 {
-			rows, err = dchartpkg.LoadSeason(*season)
+			rows, err = dchartpkg.LoadSeason(ctx, *season)
 		} else {
-			rows, err = dchartpkg.Load()
+			rows, err = dchartpkg.Load(ctx)
 		}
 		if err != nil {
 			log.Fatal(err)
@@ -219,10 +221,30 @@ func main() {
 		fmt.Printf("ff_playerids: %d rows\n", len(rows))
 		printJSONRows(rowsToAny(rows, *limit))
 
+	case "pbp":
+		if *season == 0 {
+			log.Fatal("pbp requires -season (play-by-play is published per season)")
+		}
+		rows, err := pbppkg.LoadSeason(ctx, *season)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rows = filter(rows, func(r pbppkg.PlayByPlay) bool {
+			if *week != 0 && r.Week != *week {
+				return false
+			}
+			if *seasonType != "" && !strings.EqualFold(r.SeasonType, *seasonType) {
+				return false
+			}
+			return true
+		})
+		fmt.Printf("pbp: %d rows (after filters)\n", len(rows))
+		printJSONRows(rowsToAny(rows, *limit))
+
 	case "players_components":
 
 	default:
-		log.Fatalf("unknown dataset: %s (use players|snapcounts|playerstats|rosters|rosters_weekly|teamstats|depth_charts|injuries|ff_playerids)", *dataset)
+		log.Fatalf("unknown dataset: %s (use players|snapcounts|playerstats|rosters|rosters_weekly|teamstats|depth_charts|injuries|ff_playerids|pbp)", *dataset)
 	}
 }
 
@@ -398,7 +420,7 @@ func printJSONRows(v any) {
 // 		printJSONRows(rowsToAny(rows, *limit))
 
 // 	case "depth_charts":
-// 		rows, err := dchartpkg.Load()
+// 		rows, err := dchartpkg.Load(ctx)
 // 		if err != nil {
 // 			log.Fatal(err)
 // 		}
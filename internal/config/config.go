@@ -1,45 +1,59 @@
-// Package nflreadgo: config.go
+// Package config is the single configuration model for nflreadgo. Every
+// downloader and cache used by the loaders (internal datasets packages and
+// the public pkg/nflreadgo API) is derived from an AppConfig.
 //
-// This file provides a Go equivalent of the Python `config.py` used by
-// nflreadpy. It defines:
+// It is a Go equivalent of the Python `config.py` used by nflreadpy and
+// defines:
 //   - CacheMode and preferred data format settings
-//   - A package-level Config with sane defaults
-//   - Environment variable overrides compatible with nflreadpy
-//   - NFLREADPY_CACHE              (memory|filesystem|off)
-//   - NFLREADPY_CACHE_DIR          (path)
-//   - NFLREADPY_CACHE_DURATION     (seconds)
-//   - NFLREADPY_PREFER             (parquet|csv)
-//   - NFLREADPY_VERBOSE            (true|false)
-//   - NFLREADPY_TIMEOUT            (seconds)
-//   - NFLREADPY_USER_AGENT         (string)
-//   - Functions to get/update/reset the config and to apply it to the
-//     default downloader and cache.
+//   - A package-level config with sane defaults
+//   - Environment variable overrides. Every knob is read with both the
+//     NFLREADGO_ and the nflreadpy-compatible NFLREADPY_ prefix (NFLREADGO_
+//     wins when both are set):
+//   - NFLREADGO_CACHE / NFLREADPY_CACHE                   (memory|filesystem|off)
+//   - NFLREADGO_CACHE_DIR / NFLREADPY_CACHE_DIR           (path)
+//   - NFLREADGO_CACHE_DURATION / NFLREADPY_CACHE_DURATION (seconds; _CACHE_TTL is an alias)
+//   - NFLREADGO_CACHE_MAX_BYTES / NFLREADPY_CACHE_MAX_BYTES (bytes; K/M/G suffixes allowed)
+//   - NFLREADGO_PREFER / NFLREADPY_PREFER                 (parquet|csv|csv.gz)
+//   - NFLREADGO_VERBOSE / NFLREADPY_VERBOSE               (true|false)
+//   - NFLREADGO_TIMEOUT / NFLREADPY_TIMEOUT               (seconds)
+//   - NFLREADGO_USER_AGENT / NFLREADPY_USER_AGENT         (string)
+//   - NFLREADGO_WORKERS / NFLREADPY_WORKERS               (seasons loaded in parallel)
+//   - NFLREADGO_RETRY_MAX_ATTEMPTS / NFLREADPY_RETRY_MAX_ATTEMPTS (attempts per download; 1 disables retries)
+//   - NFLREADGO_PARSED_CACHE / NFLREADPY_PARSED_CACHE     (true|false; cache decoded rows)
+//   - NFLREADGO_OFFLINE / NFLREADPY_OFFLINE               (true|false; serve only from cache)
+//   - NFLREADGO_STALE_IF_ERROR / NFLREADPY_STALE_IF_ERROR (true|false)
+//   - NFLREADGO_VERIFY_CHECKSUMS / NFLREADPY_VERIFY_CHECKSUMS (true|false)
+//   - Functions to get/update/reset the config and to build the downloader
+//     and cache it describes.
+//
+// Precedence (highest first): explicit options (UpdateConfig, Resolve, or
+// the nflreadgo.Option list) > process environment > `.env` file > defaults.
 //
 // Notes
 //   - `.env` support: if a `.env` file is present in the working directory,
 //     we parse simple KEY=VALUE lines and use them as fallbacks when OS env
 //     vars are absent (comments and blank lines are ignored).
-//   - The defaults mirror the Python module: memory cache, 24h TTL, Parquet
-//     preferred, verbose on, 30s timeout, and an nflverse-flavored UA.
+//   - Defaults: filesystem cache under the user cache dir (as nflreadpy's
+//     platformdirs location), 24h TTL, Parquet preferred, 30s timeout, and
+//     the library's own User-Agent.
 package config
 
 import (
 	"bufio"
 	"errors"
-	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
-	"runtime"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	cachepkg "github.com/tyler180/nfl-data-go/internal/cache"
 	downloadpkg "github.com/tyler180/nfl-data-go/internal/download"
 )
 
-// Version is the library version used in the default User-Agent.
+// Version is the library version.
 const Version = "v0.1.0"
 
 // CacheMode controls where downloaded data is cached.
@@ -59,45 +73,91 @@ const (
 	DataFormatCSV     DataFormat = "csv"
 )
 
-// AppConfig contains user-configurable settings. It is intentionally
-// independent from Downloader.Config to keep a stable public surface.
+// AppConfig contains user-configurable settings. pkg/nflreadgo exposes it
+// as nflreadgo.Config.
 type AppConfig struct {
-	CacheMode     CacheMode
-	CacheDir      string
-	CacheDuration time.Duration // TTL for cache entries
+	CacheMode CacheMode
+	CacheDir  string
+	CacheTTL  time.Duration // TTL for cache entries (nflreadpy: cache_duration)
+	// CacheMaxBytes caps the cache size; least recently used entries are
+	// evicted past it. 0 means unbounded.
+	CacheMaxBytes int64
+	// Backend, when set, is the cache to use instead of the one CacheMode,
+	// CacheDir, CacheTTL and CacheMaxBytes describe (e.g. a layered or S3
+	// cache).
+	Backend downloadpkg.Cache
+	// ParsedCache also caches decoded typed rows (keyed by upstream ETag and
+	// model schema version), so warm loads skip parsing. It needs the
+	// filesystem cache; see ParsedBackend.
+	ParsedCache bool
 
 	Prefer    downloadpkg.Format // preferred download format
 	Verbose   bool
 	Timeout   time.Duration // HTTP timeout
 	UserAgent string
+
+	// Retry is how downloads retry transient failures; RetryHook, when set,
+	// is called after every HTTP attempt.
+	Retry     downloadpkg.RetryPolicy
+	RetryHook func(downloadpkg.Attempt)
+
+	// Workers bounds how many seasons a multi-season load fetches and
+	// parses in parallel.
+	Workers int
+	// PartialResults makes multi-season loads return the seasons that
+	// succeeded plus an *errs.SeasonErrors instead of failing fast.
+	PartialResults bool
+
+	// Offline serves every asset from the cache, whatever its age, and
+	// never touches the network; uncached assets fail with errs.ErrNotCached.
+	Offline bool
+	// StaleIfError falls back to an expired cache entry when revalidating
+	// it fails with a transient error.
+	StaleIfError bool
+	// VerifyChecksums checks release assets against the SHA-256 digests in
+	// their GitHub release manifest (one extra, cacheable request per
+	// release). Length and error-page checks always apply.
+	VerifyChecksums bool
+
+	// Clock supplies "now" for season/week resolution; nil means time.Now.
+	Clock func() time.Time
 }
 
-// defaultCacheDir attempts to mirror platformdirs.user_cache_dir("nflreadpy").
+// defaultCacheDir mirrors platformdirs.user_cache_dir("nflreadgo").
 func defaultCacheDir() string {
 	if d, err := os.UserCacheDir(); err == nil && d != "" {
-		return filepath.Join(d, "nflreadpy") // keep python-compatible folder name
+		return filepath.Join(d, "nflreadgo")
 	}
-	return filepath.Join(os.TempDir(), "nflreadpy")
+	return filepath.Join(os.TempDir(), "nflreadgo")
 }
 
+// defaultUserAgent is the User-Agent sent unless configured otherwise.
+const defaultUserAgent = "nflreadgo/0.1 (+github.com/tyler180/nfl-data-go)"
+
 // DefaultAppConfig returns library defaults analogous to nflreadpy.
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
-		CacheMode:     CacheModeMemory,
-		CacheDir:      defaultCacheDir(),
-		CacheDuration: 24 * time.Hour,
-		Prefer:        downloadpkg.FormatParquet,
-		Verbose:       true,
-		Timeout:       30 * time.Second,
-		UserAgent:     fmt.Sprintf("nflverse/nflreadgo %s (%s)", Version, runtime.Version()),
+		CacheMode:   CacheModeFilesystem,
+		CacheDir:    defaultCacheDir(),
+		CacheTTL:    24 * time.Hour,
+		Prefer:      downloadpkg.FormatParquet,
+		ParsedCache: true,
+		Timeout:     30 * time.Second,
+		UserAgent:   defaultUserAgent,
+		Retry:       downloadpkg.DefaultRetryPolicy(),
+		Workers:     4,
 	}
 }
 
 var (
-	cfgMu       sync.RWMutex
-	globalCfg   *AppConfig
-	dotenvOnce  sync.Once
-	dotenvPairs map[string]string
+	cfgMu        sync.RWMutex
+	globalCfg    *AppConfig
+	globalClient *downloadpkg.Client
+	dotenvPairs  map[string]string
+
+	// dotenvPath is the .env file consulted by ResetConfig (relative to the
+	// working directory).
+	dotenvPath = ".env"
 )
 
 // GetConfig returns a snapshot pointer to the current config.
@@ -108,6 +168,26 @@ func GetConfig() *AppConfig {
 	return &c
 }
 
+// Resolve returns a copy of the current config with opts applied on top, so
+// explicit options outrank env/.env/defaults without touching the global.
+func Resolve(opts ...ConfigOption) *AppConfig {
+	c := GetConfig()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// Client returns the shared download client built from the current global
+// config. Loaders fall back to it when no per-call config is supplied.
+func Client() *downloadpkg.Client {
+	cfgMu.RLock()
+	defer cfgMu.RUnlock()
+	return globalClient
+}
+
 // ResetConfig resets the global config to defaults, then applies .env and
 // environment variable overrides, and wires the downloader+cache to match.
 func ResetConfig() {
@@ -146,10 +226,21 @@ func WithCacheDir(dir string) ConfigOption {
 func WithCacheDuration(ttl time.Duration) ConfigOption {
 	return func(c *AppConfig) {
 		if ttl >= 0 {
-			c.CacheDuration = ttl
+			c.CacheTTL = ttl
 		}
 	}
 }
+func WithCacheMaxBytes(n int64) ConfigOption {
+	return func(c *AppConfig) {
+		if n >= 0 {
+			c.CacheMaxBytes = n
+		}
+	}
+}
+func WithCacheBackend(b downloadpkg.Cache) ConfigOption {
+	return func(c *AppConfig) { c.Backend = b }
+}
+func WithParsedCache(v bool) ConfigOption                { return func(c *AppConfig) { c.ParsedCache = v } }
 func WithPreferFormat(f downloadpkg.Format) ConfigOption { return func(c *AppConfig) { c.Prefer = f } }
 func WithVerbose(v bool) ConfigOption                    { return func(c *AppConfig) { c.Verbose = v } }
 func WithTimeout(d time.Duration) ConfigOption {
@@ -166,63 +257,196 @@ func WithUserAgent(ua string) ConfigOption {
 		}
 	}
 }
+func WithRetry(p downloadpkg.RetryPolicy) ConfigOption {
+	return func(c *AppConfig) { c.Retry = p }
+}
+func WithRetryHook(fn func(downloadpkg.Attempt)) ConfigOption {
+	return func(c *AppConfig) { c.RetryHook = fn }
+}
+func WithClock(now func() time.Time) ConfigOption { return func(c *AppConfig) { c.Clock = now } }
+func WithWorkers(n int) ConfigOption {
+	return func(c *AppConfig) {
+		if n > 0 {
+			c.Workers = n
+		}
+	}
+}
+func WithPartialResults(v bool) ConfigOption  { return func(c *AppConfig) { c.PartialResults = v } }
+func WithOffline(v bool) ConfigOption         { return func(c *AppConfig) { c.Offline = v } }
+func WithStaleIfError(v bool) ConfigOption    { return func(c *AppConfig) { c.StaleIfError = v } }
+func WithVerifyChecksums(v bool) ConfigOption { return func(c *AppConfig) { c.VerifyChecksums = v } }
 
-// applyToSubsystems wires the downloader and the package-level cache
-// to reflect the current global configuration.
+// applyToSubsystems rebuilds the shared download client (and its cache) to
+// reflect the current global configuration.
 func applyToSubsystems(c *AppConfig) {
-	// Configure the package-level cache
+	globalClient = c.NewClient()
+}
+
+// --- Subsystems built from a config ---
+
+// Now returns the current time from Clock, or time.Now when unset.
+func (c AppConfig) Now() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// HTTPClient returns an http.Client that respects Timeout.
+func (c AppConfig) HTTPClient() *http.Client {
+	return &http.Client{Timeout: c.Timeout}
+}
+
+// cacheKey identifies a cache backend; configs that agree on it share one
+// instance so in-memory entries survive across loader calls.
+type cacheKey struct {
+	mode     CacheMode
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+	rows     bool // the parsed-rows tier (see ParsedBackend)
+}
+
+var caches sync.Map // cacheKey → downloadpkg.Cache
+
+// CacheBackend returns the download.Cache described by the config, or nil
+// when caching is off. Backends are shared between equal configs.
+func (c AppConfig) CacheBackend() downloadpkg.Cache {
+	if c.Backend != nil {
+		return c.Backend
+	}
+	k := cacheKey{mode: c.CacheMode, dir: c.CacheDir, ttl: c.CacheTTL, maxBytes: c.CacheMaxBytes}
 	switch c.CacheMode {
-	case CacheModeMemory:
-		_ = cachepkg.SetCacheOptions(cachepkg.CacheMemory, c.CacheDuration, c.CacheDir, 256)
-	case CacheModeFilesystem:
-		_ = cachepkg.SetCacheOptions(cachepkg.CacheDisk, c.CacheDuration, c.CacheDir, 0)
 	case CacheModeOff:
-		_ = cachepkg.SetCacheOptions(cachepkg.CacheOff, c.CacheDuration, c.CacheDir, 0)
+		return nil
+	case CacheModeFilesystem:
+		if c.CacheDir == "" {
+			return nil
+		}
 	default:
-		_ = cachepkg.SetCacheOptions(cachepkg.CacheMemory, c.CacheDuration, c.CacheDir, 256)
+		k.mode, k.dir = CacheModeMemory, ""
+	}
+	if v, ok := caches.Load(k); ok {
+		return v.(downloadpkg.Cache)
+	}
+	var cache downloadpkg.Cache
+	if k.mode == CacheModeFilesystem {
+		cache = downloadpkg.NewFSCache(k.dir, k.ttl, downloadpkg.WithMaxBytes(k.maxBytes))
+	} else {
+		cache = downloadpkg.NewMemCache(k.ttl, downloadpkg.WithMaxBytes(k.maxBytes))
+	}
+	v, _ := caches.LoadOrStore(k, cache)
+	return v.(downloadpkg.Cache)
+}
+
+// ParsedBackend returns the cache for decoded rows, or nil when there is
+// none. It exists only with ParsedCache and the filesystem cache: a
+// directory of its own, <CacheDir>/rows, so its entries stay out of the
+// cache index and a memory cache isn't filled twice. Custom backends
+// (Backend) get none.
+func (c AppConfig) ParsedBackend() downloadpkg.Cache {
+	if !c.ParsedCache || c.Backend != nil || c.CacheMode != CacheModeFilesystem || c.CacheDir == "" {
+		return nil
+	}
+	k := cacheKey{mode: c.CacheMode, dir: filepath.Join(c.CacheDir, "rows"), ttl: c.CacheTTL, maxBytes: c.CacheMaxBytes, rows: true}
+	if v, ok := caches.Load(k); ok {
+		return v.(downloadpkg.Cache)
+	}
+	v, _ := caches.LoadOrStore(k, downloadpkg.NewFSCache(k.dir, k.ttl, downloadpkg.WithMaxBytes(k.maxBytes)))
+	return v.(downloadpkg.Cache)
+}
+
+// clientKey identifies a download client; configs that agree on it share one
+// instance so concurrent loads of the same asset share its download.
+type clientKey struct {
+	cache        downloadpkg.Cache
+	userAgent    string
+	timeout      time.Duration
+	retry        downloadpkg.RetryPolicy
+	offline      bool
+	staleIfError bool
+}
+
+var clients sync.Map // clientKey → *downloadpkg.Client
+
+// NewClient returns a download client wired to the config's user agent,
+// timeout, cache, retry policy and offline/stale-if-error modes. Clients are
+// shared between equal configs, except those with a RetryHook or a cache
+// that can't be compared.
+func (c AppConfig) NewClient() *downloadpkg.Client {
+	cache := c.CacheBackend()
+	build := func() *downloadpkg.Client {
+		return downloadpkg.New(
+			downloadpkg.WithUserAgent(c.UserAgent),
+			downloadpkg.WithHTTPClient(c.HTTPClient()),
+			downloadpkg.WithCache(cache),
+			downloadpkg.WithRetry(c.Retry),
+			downloadpkg.WithRetryHook(c.RetryHook),
+			downloadpkg.WithOffline(c.Offline),
+			downloadpkg.WithStaleIfError(c.StaleIfError),
+		)
 	}
+	if c.RetryHook != nil || (cache != nil && !reflect.TypeOf(cache).Comparable()) {
+		return build()
+	}
+	k := clientKey{cache: cache, userAgent: c.UserAgent, timeout: c.Timeout, retry: c.Retry, offline: c.Offline, staleIfError: c.StaleIfError}
+	if v, ok := clients.Load(k); ok {
+		return v.(*downloadpkg.Client)
+	}
+	v, _ := clients.LoadOrStore(k, build())
+	return v.(*downloadpkg.Client)
 }
 
 // --- Environment & .env helpers ---
 
 func loadDotEnvIfPresent() {
-	dotenvOnce.Do(func() {
-		dotenvPairs = make(map[string]string)
-		f, err := os.Open(".env")
-		if err != nil {
-			return
+	dotenvPairs = make(map[string]string)
+	f, err := os.Open(dotenvPath)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
-		defer f.Close()
-		s := bufio.NewScanner(f)
-		for s.Scan() {
-			line := strings.TrimSpace(s.Text())
-			if line == "" || strings.HasPrefix(line, "#") {
-				continue
-			}
-			if i := strings.IndexByte(line, '='); i > 0 {
-				k := strings.TrimSpace(line[:i])
-				v := strings.TrimSpace(line[i+1:])
-				// remove optional surrounding quotes
-				v = strings.Trim(v, "\"'")
-				dotenvPairs[k] = v
-			}
+		if i := strings.IndexByte(line, '='); i > 0 {
+			k := strings.TrimSpace(line[:i])
+			v := strings.TrimSpace(line[i+1:])
+			// remove optional surrounding quotes
+			v = strings.Trim(v, "\"'")
+			dotenvPairs[k] = v
 		}
-	})
+	}
 }
 
-func envOrDotenv(key string) (string, bool) {
-	if v := os.Getenv(key); v != "" {
-		return v, true
+// envPrefixes are tried in order for every setting.
+var envPrefixes = []string{"NFLREADGO_", "NFLREADPY_"}
+
+// envOrDotenv looks up a setting by its unprefixed names (e.g., "CACHE_DIR"),
+// checking the process environment under every prefix before the .env file.
+func envOrDotenv(names ...string) (string, bool) {
+	for _, n := range names {
+		for _, p := range envPrefixes {
+			if v := os.Getenv(p + n); v != "" {
+				return v, true
+			}
+		}
 	}
-	if dotenvPairs == nil {
-		return "", false
+	for _, n := range names {
+		for _, p := range envPrefixes {
+			if v, ok := dotenvPairs[p+n]; ok {
+				return v, true
+			}
+		}
 	}
-	v, ok := dotenvPairs[key]
-	return v, ok
+	return "", false
 }
 
 func applyEnvOverrides(c *AppConfig) {
-	if v, ok := envOrDotenv("NFLREADPY_CACHE"); ok {
+	if v, ok := envOrDotenv("CACHE"); ok {
 		s := strings.ToLower(strings.TrimSpace(v))
 		switch s {
 		case "memory":
@@ -233,37 +457,98 @@ func applyEnvOverrides(c *AppConfig) {
 			c.CacheMode = CacheModeOff
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_CACHE_DIR"); ok {
+	if v, ok := envOrDotenv("CACHE_DIR"); ok {
 		if v != "" {
 			c.CacheDir = v
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_CACHE_DURATION"); ok {
+	if v, ok := envOrDotenv("CACHE_DURATION", "CACHE_TTL"); ok {
 		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n >= 0 {
-			c.CacheDuration = time.Duration(n) * time.Second
+			c.CacheTTL = time.Duration(n) * time.Second
+		}
+	}
+	if v, ok := envOrDotenv("CACHE_MAX_BYTES"); ok {
+		if n, err := parseSize(v); err == nil {
+			c.CacheMaxBytes = n
+		}
+	}
+	if v, ok := envOrDotenv("PARSED_CACHE"); ok {
+		if b, err := parseBool(v); err == nil {
+			c.ParsedCache = b
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_PREFER"); ok {
+	if v, ok := envOrDotenv("PREFER"); ok {
 		if f, err := downloadpkg.ParseFormat(v); err == nil {
 			c.Prefer = f
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_VERBOSE"); ok {
+	if v, ok := envOrDotenv("VERBOSE"); ok {
 		if b, err := parseBool(v); err == nil {
 			c.Verbose = b
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_TIMEOUT"); ok {
+	if v, ok := envOrDotenv("TIMEOUT"); ok {
 		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
 			c.Timeout = time.Duration(n) * time.Second
 		}
 	}
-	if v, ok := envOrDotenv("NFLREADPY_USER_AGENT"); ok {
+	if v, ok := envOrDotenv("USER_AGENT"); ok {
 		v = strings.TrimSpace(v)
 		if v != "" {
 			c.UserAgent = v
 		}
 	}
+	if v, ok := envOrDotenv("OFFLINE"); ok {
+		if b, err := parseBool(v); err == nil {
+			c.Offline = b
+		}
+	}
+	if v, ok := envOrDotenv("STALE_IF_ERROR"); ok {
+		if b, err := parseBool(v); err == nil {
+			c.StaleIfError = b
+		}
+	}
+	if v, ok := envOrDotenv("VERIFY_CHECKSUMS"); ok {
+		if b, err := parseBool(v); err == nil {
+			c.VerifyChecksums = b
+		}
+	}
+	if v, ok := envOrDotenv("WORKERS"); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			c.Workers = n
+		}
+	}
+	if v, ok := envOrDotenv("RETRY_MAX_ATTEMPTS"); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			c.Retry.MaxAttempts = n
+		}
+	}
+}
+
+// parseSize parses a byte count with an optional binary K/M/G suffix
+// ("512M", "2GB", "1048576").
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "B"), "I")
+	mult := int64(1)
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		}
+		if mult > 1 {
+			s = s[:n-1]
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid size")
+	}
+	return n * mult, nil
 }
 
 func parseBool(s string) (bool, error) {
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	downloadpkg "github.com/tyler180/nfl-data-go/internal/download"
+)
+
+func TestPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	env := filepath.Join(dir, ".env")
+	dotenv := "NFLREADPY_CACHE=filesystem\nNFLREADPY_CACHE_DIR=/from/dotenv\nNFLREADGO_TIMEOUT=7\nNFLREADPY_PREFER=csv\n"
+	if err := os.WriteFile(env, []byte(dotenv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := dotenvPath
+	dotenvPath = env
+	t.Cleanup(func() { dotenvPath = old; ResetConfig() })
+
+	t.Setenv("NFLREADPY_CACHE_DIR", "/from/env") // env beats .env
+	t.Setenv("NFLREADGO_TIMEOUT", "")            // unset: .env applies
+	t.Setenv("NFLREADPY_PREFER", "parquet")      // env (py prefix) beats .env
+	t.Setenv("NFLREADGO_PREFER", "csv.gz")       // NFLREADGO_ beats NFLREADPY_
+	t.Setenv("NFLREADGO_CACHE_TTL", "60")        // alias of CACHE_DURATION
+	t.Setenv("NFLREADPY_RETRY_MAX_ATTEMPTS", "2")
+	ResetConfig()
+
+	c := GetConfig()
+	if c.CacheMode != CacheModeFilesystem {
+		t.Errorf("CacheMode = %q, want filesystem from .env", c.CacheMode)
+	}
+	if c.CacheDir != "/from/env" {
+		t.Errorf("CacheDir = %q, want env value", c.CacheDir)
+	}
+	if c.Timeout != 7*time.Second {
+		t.Errorf("Timeout = %v, want 7s from .env", c.Timeout)
+	}
+	if c.Prefer != downloadpkg.FormatCSVGzip {
+		t.Errorf("Prefer = %v, want csv.gz from NFLREADGO_PREFER", c.Prefer)
+	}
+	if c.CacheTTL != time.Minute {
+		t.Errorf("CacheTTL = %v, want 1m", c.CacheTTL)
+	}
+	if want := DefaultAppConfig().Retry; c.Retry.MaxAttempts != 2 || c.Retry.BaseDelay != want.BaseDelay {
+		t.Errorf("Retry = %+v, want default policy with 2 attempts", c.Retry)
+	}
+	if c.UserAgent != DefaultAppConfig().UserAgent {
+		t.Errorf("UserAgent = %q, want default", c.UserAgent)
+	}
+
+	// Explicit options beat everything, without mutating the global.
+	r := Resolve(WithCacheDir("/explicit"), WithTimeout(time.Second))
+	if r.CacheDir != "/explicit" || r.Timeout != time.Second {
+		t.Errorf("Resolve = %+v", r)
+	}
+	if GetConfig().CacheDir != "/from/env" {
+		t.Error("Resolve mutated the global config")
+	}
+}
+
+func TestCacheBackendShared(t *testing.T) {
+	a := AppConfig{CacheMode: CacheModeMemory, CacheTTL: time.Hour}
+	b := AppConfig{CacheMode: CacheModeMemory, CacheTTL: time.Hour, UserAgent: "other"}
+	if a.CacheBackend() == nil || a.CacheBackend() != b.CacheBackend() {
+		t.Fatal("equal cache settings should share one backend")
+	}
+	if (AppConfig{CacheMode: CacheModeOff}).CacheBackend() != nil {
+		t.Fatal("CacheModeOff should have no backend")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{"1048576": 1 << 20, "512M": 512 << 20, "2GB": 2 << 30, "64KiB": 64 << 10} {
+		if got, err := parseSize(in); err != nil || got != want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if _, err := parseSize("lots"); err == nil {
+		t.Error("parseSize(lots) succeeded")
+	}
+}
+
+func TestNewClientShared(t *testing.T) {
+	a := AppConfig{CacheMode: CacheModeMemory, CacheTTL: time.Hour, UserAgent: "ua"}
+	if a.NewClient() != a.NewClient() {
+		t.Fatal("equal configs should share one client")
+	}
+	b := a
+	b.UserAgent = "other"
+	if a.NewClient() == b.NewClient() {
+		t.Fatal("configs with different user agents must not share a client")
+	}
+	a.RetryHook = func(downloadpkg.Attempt) {}
+	if a.NewClient() == a.NewClient() {
+		t.Fatal("a client with a retry hook should not be shared")
+	}
+}
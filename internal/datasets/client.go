@@ -0,0 +1,59 @@
+package datasets
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
+
+type (
+	clientKey   struct{}
+	configKey   struct{}
+	resolverKey struct{}
+)
+
+// WithClient returns a ctx whose dataset loads fetch through dl instead of
+// the shared client built from the global config.
+func WithClient(ctx context.Context, dl *download.Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, dl)
+}
+
+// WithConfig returns a ctx whose dataset loads use cfg for everything: the
+// download client (cache, timeout, user agent) and the preferred format. The
+// public nflreadgo loaders use this to apply their Option list.
+func WithConfig(ctx context.Context, cfg *config.AppConfig) context.Context {
+	ctx = context.WithValue(ctx, configKey{}, cfg)
+	return WithClient(ctx, cfg.NewClient())
+}
+
+// clientFrom returns the ctx-scoped client, or the global config's client.
+func clientFrom(ctx context.Context) *download.Client {
+	if dl, ok := ctx.Value(clientKey{}).(*download.Client); ok && dl != nil {
+		return dl
+	}
+	return config.Client()
+}
+
+// configFrom returns the ctx-scoped config, or a snapshot of the global one.
+func configFrom(ctx context.Context) *config.AppConfig {
+	if cfg, ok := ctx.Value(configKey{}).(*config.AppConfig); ok && cfg != nil {
+		return cfg
+	}
+	return config.GetConfig()
+}
+
+// WithResolver returns a ctx whose dataset loads build URLs with r instead
+// of source.DefaultResolver (e.g., source.RawResolver{} or a mirror).
+func WithResolver(ctx context.Context, r source.Resolver) context.Context {
+	return context.WithValue(ctx, resolverKey{}, r)
+}
+
+// resolverFrom returns the ctx-scoped resolver, or source.DefaultResolver.
+func resolverFrom(ctx context.Context) source.Resolver {
+	if r, ok := ctx.Value(resolverKey{}).(source.Resolver); ok && r != nil {
+		return r
+	}
+	return source.DefaultResolver
+}
@@ -3,11 +3,14 @@
 
 package depthcharts
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestLoadSeason_DepthCharts_Integration(t *testing.T) {
 	year := 2023
-	rows, err := LoadSeason(year)
+	rows, err := LoadSeason(context.Background(), year)
 	if err != nil {
 		t.Fatalf("LoadSeason(%d) error: %v", year, err)
 	}
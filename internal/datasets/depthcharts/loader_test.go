@@ -0,0 +1,41 @@
+package depthcharts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
+
+const fixtureURL = "https://raw.githubusercontent.com/nflverse/nflverse-data/master/data/depth_charts/depth_charts.csv"
+
+func TestLoadRawWithContext_UsesConfiguredCache(t *testing.T) {
+	csv := "season,club_code,full_name\n2024,KC,Patrick Mahomes\n"
+	fsys := fstest.MapFS{
+		"raw.githubusercontent.com/nflverse/nflverse-data/master/data/depth_charts/depth_charts.csv": {Data: []byte(csv)},
+	}
+	cfg := config.Resolve(
+		config.WithCacheBackend(download.NewFixtureCache(fsys)),
+		config.WithOffline(true),
+		config.WithPreferFormat(download.FormatCSV),
+	)
+	ctx := datasets.WithResolver(datasets.WithConfig(context.Background(), cfg), source.RawResolver{})
+
+	b, url, err := LoadRawWithContext(ctx)
+	if err != nil || string(b) != csv || url != fixtureURL {
+		t.Fatalf("LoadRawWithContext = %q, %q, %v; want the cached CSV", b, url, err)
+	}
+
+	// Offline with nothing cached fails instead of reaching the network.
+	cfg = config.Resolve(config.WithCacheBackend(download.NewFixtureCache(fstest.MapFS{})), config.WithOffline(true))
+	ctx = datasets.WithResolver(datasets.WithConfig(context.Background(), cfg), source.RawResolver{})
+	if _, _, err := LoadRawWithContext(ctx); !errors.Is(err, errs.ErrNotCached) {
+		t.Fatalf("offline miss: err = %v, want ErrNotCached", err)
+	}
+}
@@ -6,11 +6,11 @@ import (
 	"github.com/tyler180/nfl-data-go/internal/datasets"
 )
 
-// DynastyProcess source (not season-scoped)
-var src = datasets.Source{Repo: "dynastyprocess", Base: "db_playerids"}
+// DynastyProcess source (not season-scoped; served from the repo, not releases)
+var src = datasets.Source{Repo: "dynastyprocess/data", Base: "files/db_playerids"}
 
 func Load(ctx context.Context) ([]FFPlayerID, error) {
-	return datasets.LoadFromSourceAs[FFPlayerID](ctx, src, 0, FromMap)
+	return datasets.LoadDatasetAs[FFPlayerID](ctx, src, 0, FromMap)
 }
 
 // func LoadRaw(ctx context.Context) ([]byte, string, error) {
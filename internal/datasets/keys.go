@@ -12,16 +12,24 @@ const (
 	TeamStatsWeekly Key = "teamstats_week"
 	DepthCharts     Key = "depth_charts"
 	Injuries        Key = "injuries"
+	PlayByPlay      Key = "pbp"
+
+	PlayerStatsWeekly Key = "playerstats_week"
+	Schedules         Key = "schedules" // nflverse/nfldata, not in pathByKey
 )
 
-// pathByKey maps dataset keys to their nflverse-data repo paths (base names).
+// pathByKey maps dataset keys to their nflverse-data paths (base names):
+// "<release tag>/<asset stem>", see source.ReleaseAssetFor.
 var pathByKey = map[Key]string{
 	Players:         "players/players",
 	SnapCounts:      "snap_counts/snap_counts",
 	PlayerStats:     "player_stats/player_stats", // generic base (for legacy use)
-	Rosters:         "rosters/rosters",
-	RostersWeekly:   "weekly_rosters/weekly_rosters",
+	Rosters:         "rosters/roster",
+	RostersWeekly:   "weekly_rosters/roster_weekly",
 	TeamStatsWeekly: "stats_team/stats_team_week",
 	DepthCharts:     "depth_charts/depth_charts",
 	Injuries:        "injuries/injuries",
+	PlayByPlay:      "pbp/play_by_play",
+
+	PlayerStatsWeekly: "stats_player/stats_player_week",
 }
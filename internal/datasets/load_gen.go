@@ -2,46 +2,65 @@ package datasets
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"io"
+	"iter"
 
-	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
 	"github.com/tyler180/nfl-data-go/internal/parse"
-	"github.com/tyler180/nfl-data-go/internal/source"
 )
 
 // LoadRaw returns the raw bytes and provenance URL for a dataset key.
 func LoadRaw(ctx context.Context, key Key) ([]byte, string, error) {
 	path, ok := pathByKey[key]
 	if !ok {
-		return nil, "", fmt.Errorf("unknown dataset: %s", key)
+		return nil, "", errs.Wrap(string(key), "", errors.New("unknown dataset"))
 	}
 
-	// Build raw.githubusercontent URL (owner defaults to nflverse if not provided)
-	url := source.RawGitHubURL("nflverse/nflverse-data", path)
-
-	// Create a downloader (you can add options: WithCache, WithUserAgent, etc.)
-	dl := download.New()
-	rc, _, err := dl.Fetch(ctx, url)
+	// Resolve the URL (release asset by default, see WithResolver) using the
+	// preferred format. The downloader comes from ctx (see WithClient).
+	dl := clientFrom(ctx)
+	rc, asset, err := openAsset(ctx, dl, resolverFrom(ctx), nflverseData, path, configFrom(ctx).Prefer)
 	if err != nil {
-		return nil, "", err
+		return nil, "", errs.Wrap(string(key), "", err)
 	}
 	defer rc.Close()
 
 	b, err := io.ReadAll(rc)
 	if err != nil {
-		return nil, "", err
+		return nil, "", errs.Wrap(string(key), asset.URL, err)
 	}
-	return b, url, nil
+	return b, asset.URL, nil
 }
 
-// LoadRows returns generic []map[string]any using the parser's auto-detection (CSV now; Parquet TODO).
+// LoadRows returns generic []map[string]any using the parser's auto-detection (CSV or Parquet).
 func LoadRows(ctx context.Context, key Key) ([]map[string]any, error) {
 	b, usedURL, err := LoadRaw(ctx, key)
 	if err != nil {
 		return nil, err
 	}
-	return parse.Auto(b, usedURL)
+	rows, err := parse.Auto(b, usedURL)
+	return rows, errs.Wrap(string(key), usedURL, err)
+}
+
+// StreamRows is the streaming form of LoadRows: generic rows are decoded one
+// at a time from the download stream. Iteration stops at the first error.
+func StreamRows(ctx context.Context, key Key) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		path, ok := pathByKey[key]
+		if !ok {
+			yield(nil, errs.Wrap(string(key), "", errors.New("unknown dataset")))
+			return
+		}
+		rc, asset, err := openAsset(ctx, clientFrom(ctx), resolverFrom(ctx), nflverseData, path, configFrom(ctx).Prefer)
+		if err != nil {
+			yield(nil, errs.Wrap(string(key), "", err))
+			return
+		}
+		defer rc.Close()
+		rows := streamAs(ctx, rc, asset, func(m map[string]any) map[string]any { return m })
+		wrapErrs(rows, string(key), asset.URL)(yield)
+	}
 }
 
 // LoadAs provides a typed, generic loader given a mapper function.
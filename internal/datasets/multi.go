@@ -0,0 +1,86 @@
+package datasets
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// MultiOptions controls LoadSeasonsConcurrently.
+type MultiOptions struct {
+	Workers int  // max seasons in flight; <= 0 means 1
+	Partial bool // keep going after failures and report them in *errs.SeasonErrors
+}
+
+// LoadSeasonsConcurrently runs load for every season with at most
+// opts.Workers in flight and concatenates the results in the order of
+// seasons, regardless of completion order.
+//
+// By default the first failure cancels the ctx passed to the remaining loads
+// and is returned alone. With opts.Partial, every season runs to completion;
+// the rows of the seasons that succeeded are returned together with an
+// *errs.SeasonErrors describing the ones that failed, including any that
+// never started because ctx was canceled.
+func LoadSeasonsConcurrently[T any](ctx context.Context, seasons []int, opts MultiOptions, load func(context.Context, int) ([]T, error)) ([]T, error) {
+	workers := max(opts.Workers, 1)
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([][]T, len(seasons))
+	failed := make([]error, len(seasons))
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(seasons)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				rows, err := load(ctx, seasons[i])
+				if err != nil {
+					failed[i] = err
+					if !opts.Partial {
+						cancel(err)
+					}
+					continue
+				}
+				results[i] = rows
+			}
+		}()
+	}
+	fed := 0
+feed:
+	for ; fed < len(seasons); fed++ {
+		select {
+		case next <- fed:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+	for i := fed; i < len(seasons); i++ {
+		failed[i] = context.Cause(ctx) // never started
+	}
+
+	if !opts.Partial {
+		if err := context.Cause(ctx); err != nil {
+			return nil, err // the first failure (or the caller's cancellation)
+		}
+	}
+
+	var out []T
+	se := &errs.SeasonErrors{}
+	for i, rows := range results {
+		if failed[i] != nil {
+			se.Add(seasons[i], failed[i])
+			continue
+		}
+		out = append(out, rows...)
+	}
+	if len(se.Seasons) > 0 {
+		return out, se
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+func TestLoadSeasonsConcurrently_OrderAndLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	seasons := []int{2019, 2020, 2021, 2022, 2023, 2024}
+	got, err := LoadSeasonsConcurrently(context.Background(), seasons, MultiOptions{Workers: 2}, func(ctx context.Context, yr int) ([]string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		// Later seasons finish first; output order must not follow.
+		time.Sleep(time.Duration(2025-yr) * 2 * time.Millisecond)
+		return []string{fmt.Sprint(yr, "a"), fmt.Sprint(yr, "b")}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2019a", "2019b", "2020a", "2020b", "2021a", "2021b", "2022a", "2022b", "2023a", "2023b", "2024a", "2024b"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, want <= 2", peak.Load())
+	}
+}
+
+func TestLoadSeasonsConcurrently_FailFast(t *testing.T) {
+	boom := errors.New("boom")
+	var canceled atomic.Int32
+	var started sync.WaitGroup
+	started.Add(3)
+	_, err := LoadSeasonsConcurrently(context.Background(), []int{2020, 2021, 2022}, MultiOptions{Workers: 3}, func(ctx context.Context, yr int) ([]int, error) {
+		started.Done()
+		started.Wait() // every season is in flight before 2021 fails
+		if yr == 2021 {
+			return nil, boom
+		}
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return []int{yr}, nil
+		}
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+	if canceled.Load() != 2 {
+		t.Fatalf("canceled siblings = %d, want 2", canceled.Load())
+	}
+}
+
+func TestLoadSeasonsConcurrently_Partial(t *testing.T) {
+	got, err := LoadSeasonsConcurrently(context.Background(), []int{2020, 2021, 2022}, MultiOptions{Workers: 2, Partial: true}, func(ctx context.Context, yr int) ([]int, error) {
+		if yr == 2021 {
+			return nil, fmt.Errorf("season %d: %w", yr, errs.ErrNotFound)
+		}
+		return []int{yr}, nil
+	})
+	if !slices.Equal(got, []int{2020, 2022}) {
+		t.Fatalf("rows = %v, want [2020 2022]", got)
+	}
+	var se *errs.SeasonErrors
+	if !errors.As(err, &se) || !slices.Equal(se.Seasons, []int{2021}) || !errors.Is(err, errs.ErrNotFound) {
+		t.Fatalf("err = %v, want SeasonErrors for 2021 wrapping ErrNotFound", err)
+	}
+	if se.For(2020) != nil || se.For(2021) == nil {
+		t.Fatalf("For: %v / %v", se.For(2020), se.For(2021))
+	}
+}
+
+func TestLoadSeasonsConcurrently_PartialCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	got, err := LoadSeasonsConcurrently(ctx, []int{2020, 2021, 2022}, MultiOptions{Workers: 1, Partial: true}, func(ctx context.Context, yr int) ([]int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cancel() // the caller gives up after the first season
+		return []int{yr}, nil
+	})
+	if !slices.Equal(got, []int{2020}) {
+		t.Fatalf("rows = %v, want [2020]", got)
+	}
+	var se *errs.SeasonErrors
+	if !errors.As(err, &se) || !slices.Equal(se.Seasons, []int{2021, 2022}) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want SeasonErrors for 2021 and 2022 wrapping context.Canceled", err)
+	}
+}
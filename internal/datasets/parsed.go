@@ -0,0 +1,87 @@
+package datasets
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"reflect"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/datasets/rowmap"
+	"github.com/tyler180/nfl-data-go/internal/download"
+)
+
+// The parsed-rows tier: typed rows decoded from an asset are gob-encoded
+// into the config's parsed backend (a directory next to the raw file cache;
+// see config.AppConfig.ParsedBackend), so a warm load skips CSV/Parquet
+// parsing and mapping entirely. An entry is only used when it was built
+// from the same upstream ETag with the same model schema version
+// (rowmap.SchemaVersion), so new data and changed model structs both
+// invalidate it. Assets without an ETag are never cached this way.
+//
+// Only LoadDatasetAs uses the tier: its mapper is the dataset package's
+// FromMap, fixed for the dataset key and row type the entries are keyed by.
+
+// parsedKey is the cache key for dataset's T rows decoded from asset.
+func parsedKey[T any](dataset Key, asset Asset) string {
+	return "nflreadgo+rows:" + string(dataset) + ":" + reflect.TypeFor[T]().String() + "@" + asset.URL
+}
+
+// parsedTag identifies the exact rows stored under parsedKey.
+func parsedTag[T any](asset Asset) string {
+	return asset.ETag + ";schema=" + rowmap.SchemaVersion[T]()
+}
+
+// parsedCache returns the ctx config's parsed backend, if asset can be
+// cached there. A ctx given a client but no config (WithClient) has none,
+// since the tier belongs to the config the client came from.
+func parsedCache(ctx context.Context, asset Asset) download.Cache {
+	if asset.ETag == "" {
+		return nil
+	}
+	_, hasCfg := ctx.Value(configKey{}).(*config.AppConfig)
+	if _, hasClient := ctx.Value(clientKey{}).(*download.Client); hasClient && !hasCfg {
+		return nil
+	}
+	return configFrom(ctx).ParsedBackend()
+}
+
+// loadParsed returns the cached rows for asset, if current.
+func loadParsed[T any](ctx context.Context, src Source, asset Asset) ([]T, bool) {
+	cache := parsedCache(ctx, asset)
+	if cache == nil {
+		return nil, false
+	}
+	rc, meta, err := cache.Open(parsedKey[T](src.Key, asset))
+	if err != nil {
+		return nil, false
+	}
+	defer rc.Close()
+	if meta.ETag != parsedTag[T](asset) {
+		return nil, false
+	}
+	var rows []T
+	if err := gob.NewDecoder(rc).Decode(&rows); err != nil {
+		return nil, false
+	}
+	return rows, true
+}
+
+// storeParsed caches src's rows for asset, labelled with its dataset and
+// season so ClearCache can remove them along with the raw file. Failures
+// (including types gob can't encode) only cost the next load a parse, so
+// they are ignored.
+func storeParsed[T any](ctx context.Context, src Source, asset Asset, rows []T) {
+	cache := parsedCache(ctx, asset)
+	if cache == nil {
+		return
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rows); err != nil {
+		return
+	}
+	meta := download.Metadata{ETag: parsedTag[T](asset), Dataset: src.label(), Season: asset.Season}
+	if rc, err := cache.Store(parsedKey[T](src.Key, asset), meta, &buf); err == nil {
+		rc.Close()
+	}
+}
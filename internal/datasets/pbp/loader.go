@@ -0,0 +1,22 @@
+package pbp
+
+import (
+	"context"
+	"iter"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+)
+
+// NOTE: play-by-play is only published per season (play_by_play_YYYY.*).
+var src = datasets.Source{Repo: "nflverse/nflverse-data", Base: "pbp/play_by_play", Key: datasets.PlayByPlay}
+
+// LoadSeason loads every play for one season.
+func LoadSeason(ctx context.Context, season int) ([]PlayByPlay, error) {
+	return datasets.LoadDatasetAs[PlayByPlay](ctx, src, season, FromMap)
+}
+
+// StreamSeason yields plays one at a time; prefer it over LoadSeason when
+// aggregating, since a season is ~50k wide rows.
+func StreamSeason(ctx context.Context, season int) iter.Seq2[PlayByPlay, error] {
+	return datasets.StreamFromSourceAs[PlayByPlay](ctx, src, season, FromMap)
+}
@@ -0,0 +1,66 @@
+package pbp
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/download"
+)
+
+const fixtureCSV = "play_id,game_id,season,week,posteam,down,air_yards,shotgun,touchdown,epa\n" +
+	"1,2024_01_BAL_KC,2024,1,KC,1,12,1,0,0.5\n" +
+	"2,2024_01_BAL_KC,2024,1,KC,NA,NA,0,1,NA\n"
+
+func TestFromMap_RoundTrip(t *testing.T) {
+	p := FromMap(map[string]any{
+		"play_id": "40", "game_id": "2024_01_BAL_KC", "season": "2024", "down": "NA",
+		"shotgun": "1", "touchdown": "0", "epa": "-0.25", "passer_player_id": "00-0033873",
+	})
+	want := PlayByPlay{PlayID: 40, GameID: "2024_01_BAL_KC", Season: 2024, Shotgun: true, EPA: -0.25, PasserPlayerID: "00-0033873"}
+	if p != want {
+		t.Fatalf("FromMap = %+v\nwant %+v", p, want)
+	}
+	if got := FromMap(p.ToMap()); got != p {
+		t.Fatalf("FromMap(ToMap) = %+v\nwant %+v", got, p)
+	}
+}
+
+func TestLoadSeason_Fixture(t *testing.T) {
+	fsys := fstest.MapFS{
+		"github.com/nflverse/nflverse-data/releases/download/pbp/play_by_play_2024.csv": {Data: []byte(fixtureCSV)},
+	}
+	cfg := config.Resolve(
+		config.WithCacheBackend(download.NewFixtureCache(fsys)),
+		config.WithOffline(true),
+		config.WithPreferFormat(download.FormatCSV),
+	)
+	ctx := datasets.WithConfig(context.Background(), cfg)
+
+	plays, err := LoadSeason(ctx, 2024)
+	if err != nil {
+		t.Fatalf("LoadSeason: %v", err)
+	}
+	if len(plays) != 2 {
+		t.Fatalf("LoadSeason = %d plays, want 2", len(plays))
+	}
+	if p := plays[0]; p.Down != 1 || p.AirYards != 12 || !p.Shotgun || p.Touchdown || p.EPA != 0.5 {
+		t.Errorf("play 1 = %+v", p)
+	}
+	if p := plays[1]; p.Down != 0 || p.AirYards != 0 || p.Shotgun || !p.Touchdown || p.EPA != 0 {
+		t.Errorf("play 2 (NA columns) = %+v", p)
+	}
+
+	var streamed []PlayByPlay
+	for p, err := range StreamSeason(ctx, 2024) {
+		if err != nil {
+			t.Fatalf("StreamSeason: %v", err)
+		}
+		streamed = append(streamed, p)
+	}
+	if len(streamed) != len(plays) || streamed[0] != plays[0] || streamed[1] != plays[1] {
+		t.Fatalf("StreamSeason = %+v\nwant %+v", streamed, plays)
+	}
+}
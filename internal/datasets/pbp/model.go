@@ -0,0 +1,129 @@
+package pbp
+
+import "github.com/tyler180/nfl-data-go/internal/datasets/rowmap"
+
+// PlayByPlay models a single play from the nflverse play-by-play dataset
+// (nflfastR output). JSON tags mirror dataset column names. The fields below
+// cover identifiers, game situation, the EPA/WP model outputs and the
+// involved players; extend as needed.
+//
+// Notes on types:
+//   - 0/1 indicator columns (shotgun, touchdown, ...) are bools.
+//   - Nullable numerics (down, air_yards, cpoe, ...) are 0 when NA.
+//
+// Data dictionary: https://nflreadr.nflverse.com/articles/dictionary_pbp.html
+type PlayByPlay struct {
+	// identifiers
+	PlayID     int    `json:"play_id"`
+	GameID     string `json:"game_id"`
+	OldGameID  string `json:"old_game_id"`
+	Season     int    `json:"season"`
+	SeasonType string `json:"season_type"` // REG or POST
+	Week       int    `json:"week"`
+	GameDate   string `json:"game_date"` // YYYY-MM-DD
+	HomeTeam   string `json:"home_team"`
+	AwayTeam   string `json:"away_team"`
+
+	// drive & series
+	Drive            int    `json:"drive"`
+	FixedDrive       int    `json:"fixed_drive"`
+	FixedDriveResult string `json:"fixed_drive_result"`
+	DrivePlayCount   int    `json:"drive_play_count"`
+	Series           int    `json:"series"`
+	SeriesSuccess    bool   `json:"series_success"`
+
+	// situation
+	PosTeam                 string `json:"posteam"`
+	PosTeamType             string `json:"posteam_type"` // home or away
+	DefTeam                 string `json:"defteam"`
+	SideOfField             string `json:"side_of_field"`
+	YardLine100             int    `json:"yardline_100"` // yards from opponent end zone
+	YrdLn                   string `json:"yrdln"`        // e.g., "KC 35"
+	Quarter                 int    `json:"qtr"`
+	GameHalf                string `json:"game_half"`
+	Time                    string `json:"time"` // game clock, MM:SS
+	QuarterSecondsRemaining int    `json:"quarter_seconds_remaining"`
+	HalfSecondsRemaining    int    `json:"half_seconds_remaining"`
+	GameSecondsRemaining    int    `json:"game_seconds_remaining"`
+	Down                    int    `json:"down"`
+	YdsToGo                 int    `json:"ydstogo"`
+	GoalToGo                bool   `json:"goal_to_go"`
+	PosTeamScore            int    `json:"posteam_score"`
+	DefTeamScore            int    `json:"defteam_score"`
+	ScoreDifferential       int    `json:"score_differential"`
+	PosTeamTimeouts         int    `json:"posteam_timeouts_remaining"`
+	DefTeamTimeouts         int    `json:"defteam_timeouts_remaining"`
+
+	// play
+	Desc            string `json:"desc"`
+	PlayType        string `json:"play_type"`
+	YardsGained     int    `json:"yards_gained"`
+	Shotgun         bool   `json:"shotgun"`
+	NoHuddle        bool   `json:"no_huddle"`
+	QBDropback      bool   `json:"qb_dropback"`
+	QBScramble      bool   `json:"qb_scramble"`
+	PassLength      string `json:"pass_length"`
+	PassLocation    string `json:"pass_location"`
+	AirYards        int    `json:"air_yards"`
+	YardsAfterCatch int    `json:"yards_after_catch"`
+	RunLocation     string `json:"run_location"`
+	RunGap          string `json:"run_gap"`
+	PassAttempt     bool   `json:"pass_attempt"`
+	RushAttempt     bool   `json:"rush_attempt"`
+	CompletePass    bool   `json:"complete_pass"`
+	Sack            bool   `json:"sack"`
+	Interception    bool   `json:"interception"`
+	Fumble          bool   `json:"fumble"`
+	FumbleLost      bool   `json:"fumble_lost"`
+	Touchdown       bool   `json:"touchdown"`
+	PassTouchdown   bool   `json:"pass_touchdown"`
+	RushTouchdown   bool   `json:"rush_touchdown"`
+	FirstDown       bool   `json:"first_down"`
+	Penalty         bool   `json:"penalty"`
+	Success         bool   `json:"success"`
+
+	// expected points & win probability
+	EP         float64 `json:"ep"`
+	EPA        float64 `json:"epa"`
+	AirEPA     float64 `json:"air_epa"`
+	YACEPA     float64 `json:"yac_epa"`
+	QBEPA      float64 `json:"qb_epa"`
+	WP         float64 `json:"wp"`
+	DefWP      float64 `json:"def_wp"`
+	HomeWP     float64 `json:"home_wp"`
+	AwayWP     float64 `json:"away_wp"`
+	WPA        float64 `json:"wpa"`
+	VegasWP    float64 `json:"vegas_wp"`
+	VegasWPA   float64 `json:"vegas_wpa"`
+	CP         float64 `json:"cp"`
+	CPOE       float64 `json:"cpoe"`
+	XPass      float64 `json:"xpass"`
+	PassOE     float64 `json:"pass_oe"`
+	XYACEPA    float64 `json:"xyac_epa"`
+	SpreadLine float64 `json:"spread_line"`
+	TotalLine  float64 `json:"total_line"`
+
+	// players (gsis ids)
+	PasserPlayerID     string `json:"passer_player_id"`
+	PasserPlayerName   string `json:"passer_player_name"`
+	PassingYards       int    `json:"passing_yards"`
+	RusherPlayerID     string `json:"rusher_player_id"`
+	RusherPlayerName   string `json:"rusher_player_name"`
+	RushingYards       int    `json:"rushing_yards"`
+	ReceiverPlayerID   string `json:"receiver_player_id"`
+	ReceiverPlayerName string `json:"receiver_player_name"`
+	ReceivingYards     int    `json:"receiving_yards"`
+	PasserID           string `json:"passer_id"`   // includes scrambles/sacks
+	RusherID           string `json:"rusher_id"`   // includes QB scrambles
+	ReceiverID         string `json:"receiver_id"` // includes penalties
+}
+
+// FromMap converts a generic row into a typed PlayByPlay.
+func FromMap(row map[string]any) PlayByPlay {
+	return rowmap.Decode[PlayByPlay](row)
+}
+
+// ToMap converts a PlayByPlay back to dataset-style keys.
+func (p PlayByPlay) ToMap() map[string]any {
+	return rowmap.Encode(p)
+}
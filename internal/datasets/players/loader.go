@@ -6,11 +6,11 @@ import (
 	"github.com/tyler180/nfl-data-go/internal/datasets"
 )
 
-var src = datasets.Source{Repo: "nflverse-data", Base: "players/players"}
+var src = datasets.Source{Repo: "nflverse-data", Base: "players/players", Key: datasets.Players}
 
 // All seasons snapshot (players table isn’t season-scoped upstream)
 func Load(ctx context.Context) ([]Player, error) {
-	return datasets.LoadFromSourceAs[Player](ctx, src, 0, FromMap)
+	return datasets.LoadDatasetAs[Player](ctx, src, 0, FromMap)
 }
 
 // func LoadRaw() ([]byte, string, error) {
@@ -8,7 +8,7 @@ import (
 
 // Describe the upstream sources once and reuse.
 var (
-	srcWeek    = datasets.Source{Repo: "nflverse-data", Base: "stats_player/stats_player_week"}
+	srcWeek    = datasets.Source{Repo: "nflverse-data", Base: "stats_player/stats_player_week", Key: datasets.PlayerStatsWeekly}
 	srcReg     = datasets.Source{Repo: "nflverse-data", Base: "stats_player/stats_player_reg"}
 	srcPost    = datasets.Source{Repo: "nflverse-data", Base: "stats_player/stats_player_post"}
 	srcRegPost = datasets.Source{Repo: "nflverse-data", Base: "stats_player/stats_player_reg_post"}
@@ -16,34 +16,34 @@ var (
 
 // Week-level (default all seasons).
 func Load(ctx context.Context) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcWeek, 0, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcWeek, 0, FromMap)
 }
 
 // Week-level, per-season (e.g., stats_player_week_2024.*).
 func LoadForSeason(ctx context.Context, season int) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcWeek, season, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcWeek, season, FromMap)
 }
 
 // Season-summary (REG/POST/REG+POST) across all seasons.
 func LoadSeasonReg(ctx context.Context) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcReg, 0, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcReg, 0, FromMap)
 }
 func LoadSeasonPost(ctx context.Context) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcPost, 0, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcPost, 0, FromMap)
 }
 func LoadSeasonRegPost(ctx context.Context) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcRegPost, 0, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcRegPost, 0, FromMap)
 }
 
 // Season-summary for a specific season (e.g., stats_player_reg_2024.*).
 func LoadSeasonRegForSeason(ctx context.Context, season int) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcReg, season, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcReg, season, FromMap)
 }
 func LoadSeasonPostForSeason(ctx context.Context, season int) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcPost, season, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcPost, season, FromMap)
 }
 func LoadSeasonRegPostForSeason(ctx context.Context, season int) ([]PlayerStat, error) {
-	return datasets.LoadFromSourceAs[PlayerStat](ctx, srcRegPost, season, FromMap)
+	return datasets.LoadDatasetAs[PlayerStat](ctx, srcRegPost, season, FromMap)
 }
 
 // Raw bytes for the default (week-level, all seasons) asset.
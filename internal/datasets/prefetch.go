@@ -0,0 +1,59 @@
+package datasets
+
+import (
+	"context"
+	"io"
+
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// otherSources lists the keyed datasets that don't live in nflverse-data.
+var otherSources = map[Key]Source{
+	Schedules: {Repo: "nflverse/nfldata", Base: "data/games", Key: Schedules},
+}
+
+// SourceFor returns the Source the loaders read key from.
+func SourceFor(key Key) (Source, bool) {
+	if src, ok := otherSources[key]; ok {
+		return src, true
+	}
+	path, ok := pathByKey[key]
+	if !ok {
+		return Source{}, false
+	}
+	return Source{Repo: nflverseData, Base: path, Key: key}, true
+}
+
+// Prefetch brings the asset LoadFromSourceAs would read for (src, season)
+// into the ctx client's cache without parsing it, and returns that asset and
+// its size. Asset.Status tells whether the cached copy was fresh, revalidated
+// (304) or downloaded.
+func Prefetch(ctx context.Context, src Source, season int) (Asset, int64, error) {
+	rc, asset, err := openSource(ctx, clientFrom(ctx), resolverFrom(ctx), src, season, configFrom(ctx).Prefer)
+	if err != nil {
+		return Asset{}, 0, errs.Wrap(src.name(), "", err)
+	}
+	defer rc.Close()
+	n, err := io.Copy(io.Discard, rc)
+	if err != nil {
+		return Asset{}, 0, errs.Wrap(src.name(), asset.URL, err)
+	}
+	return asset, n, nil
+}
+
+// PrefetchURL is Prefetch for a fixed URL, recorded in the cache index under
+// dataset and season.
+func PrefetchURL(ctx context.Context, dataset string, season int, url string) (Asset, int64, error) {
+	rc, meta, err := clientFrom(ctx).Fetch(download.WithAsset(ctx, dataset, season), url)
+	if err != nil {
+		return Asset{}, 0, errs.Wrap(dataset, url, err)
+	}
+	defer rc.Close()
+	n, err := io.Copy(io.Discard, rc)
+	if err != nil {
+		return Asset{}, 0, errs.Wrap(dataset, url, err)
+	}
+	f, _ := download.FormatOfPath(url)
+	return Asset{URL: url, Format: f, Season: season, Encoding: meta.ContentEncoding, ETag: meta.ETag, Status: meta.Status}, n, nil
+}
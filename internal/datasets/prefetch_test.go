@@ -0,0 +1,55 @@
+package datasets
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
+
+func TestPrefetch_Status(t *testing.T) {
+	body := "season,team\n2024,KC\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "injuries_2024.csv") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	dir := t.TempDir()
+	prefetch := func(ttl time.Duration) (Asset, int64) {
+		t.Helper()
+		dl := download.New(download.WithCache(download.NewFSCache(dir, ttl)), download.WithHTTPClient(&http.Client{Transport: rewriteTransport{u}}))
+		ctx := WithResolver(WithClient(context.Background(), dl), source.RawResolver{})
+		src, _ := SourceFor(Injuries)
+		a, n, err := Prefetch(ctx, src, 2024)
+		if err != nil {
+			t.Fatalf("Prefetch: %v", err)
+		}
+		return a, n
+	}
+
+	if a, n := prefetch(time.Hour); a.Status != download.CacheDownloaded || a.Season != 2024 || n != int64(len(body)) {
+		t.Fatalf("cold prefetch: status %v season %d size %d", a.Status, a.Season, n)
+	}
+	if a, _ := prefetch(time.Hour); a.Status != download.CacheFresh {
+		t.Fatalf("warm prefetch: status %v, want fresh", a.Status)
+	}
+	if a, _ := prefetch(time.Nanosecond); a.Status != download.CacheRevalidated {
+		t.Fatalf("expired prefetch: status %v, want revalidated", a.Status)
+	}
+}
@@ -0,0 +1,79 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
+
+// nflverseData is the repo the Key-based loaders read from.
+const nflverseData = "nflverse/nflverse-data"
+
+// ResolveRelease maps a dataset key and season (0 = the all-seasons asset)
+// in format f to its nflverse-data release tag and asset name.
+func ResolveRelease(key Key, season int, f download.Format) (source.ReleaseAsset, error) {
+	path, ok := pathByKey[key]
+	if !ok {
+		return source.ReleaseAsset{}, errs.Wrap(string(key), "", errors.New("unknown dataset"))
+	}
+	return source.ReleaseAssetFor(nflverseData, SeasonPath(path, season)+f.Ext()), nil
+}
+
+// ListAssets fetches the release manifest for key's tag and returns every
+// asset published under it (all seasons and formats).
+func ListAssets(ctx context.Context, key Key) ([]source.ReleaseAsset, error) {
+	a, err := ResolveRelease(key, 0, download.FormatParquet)
+	if err != nil {
+		return nil, err
+	}
+	assets, err := releaseAssets(ctx, clientFrom(ctx), a.Repo, a.Tag)
+	return assets, errs.Wrap(string(key), "", err)
+}
+
+// releaseAssets fetches and decodes the manifest of repo's release tag.
+func releaseAssets(ctx context.Context, dl *download.Client, repo, tag string) ([]source.ReleaseAsset, error) {
+	url := source.ReleaseManifestURL(repo, tag)
+	rc, _, err := dl.Fetch(ctx, url)
+	if err != nil {
+		return nil, errs.Wrap("", url, err)
+	}
+	defer rc.Close()
+	assets, err := source.ParseReleaseManifest(repo, tag, rc)
+	if err != nil {
+		return nil, errs.Wrap("", url, &errs.ParseError{URL: url, Err: err})
+	}
+	// Read to EOF so the download is checked and cached.
+	if _, err := io.Copy(io.Discard, rc); err != nil {
+		return nil, errs.Wrap("", url, err)
+	}
+	return assets, nil
+}
+
+// withDigest returns ctx carrying the SHA-256 that url's release manifest
+// publishes, when the config asks for checksum verification. Non-release
+// URLs, manifests that can't be read and assets without a digest are left
+// unchecked.
+func withDigest(ctx context.Context, dl *download.Client, url string) context.Context {
+	if !configFrom(ctx).VerifyChecksums || dl.Offline() {
+		return ctx
+	}
+	a, ok := source.ParseReleaseURL(url)
+	if !ok {
+		return ctx
+	}
+	// The manifest is not part of any dataset in the cache index.
+	assets, err := releaseAssets(download.WithAsset(ctx, "", 0), dl, a.Repo, a.Tag)
+	if err != nil {
+		return ctx
+	}
+	for _, x := range assets {
+		if x.Name == a.Name && x.Digest != "" {
+			return download.WithChecksum(ctx, x.Digest)
+		}
+	}
+	return ctx
+}
@@ -6,15 +6,15 @@ import (
 	"github.com/tyler180/nfl-data-go/internal/datasets"
 )
 
-var src = datasets.Source{Repo: "nflverse-data", Base: "rosters/roster"}
+var src = datasets.Source{Repo: "nflverse-data", Base: "rosters/roster", Key: datasets.Rosters}
 
 // All seasons (combined file)
 func Load(ctx context.Context) ([]Roster, error) {
-	return datasets.LoadFromSourceAs[Roster](ctx, src, 0, FromMap)
+	return datasets.LoadDatasetAs[Roster](ctx, src, 0, FromMap)
 }
 
 func LoadSeason(ctx context.Context, season int) ([]Roster, error) {
-	return datasets.LoadFromSourceAs[Roster](ctx, src, season, FromMap)
+	return datasets.LoadDatasetAs[Roster](ctx, src, season, FromMap)
 }
 
 // func LoadRaw() ([]byte, string, error) {
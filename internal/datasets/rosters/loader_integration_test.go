@@ -5,8 +5,10 @@ package rosters
 
 import (
 	"context"
-	"strings"
+	"errors"
 	"testing"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
 )
 
 func TestLoadSeason_Rosters_Integration(ctx context.Context, t *testing.T) {
@@ -15,7 +17,7 @@ func TestLoadSeason_Rosters_Integration(ctx context.Context, t *testing.T) {
 	if err != nil {
 		// Some releases don't publish per-season "rosters_<year>" files.
 		// If we see a 404, fallback to the base and at least assert non-empty.
-		if strings.Contains(err.Error(), "HTTP error 404") || strings.Contains(err.Error(), "404 Not Found") {
+		if errors.Is(err, errs.ErrNotFound) {
 			t.Logf("season-scoped rosters for %d not found; falling back to base", year)
 			rows, err = Load(ctx)
 			if err != nil {
@@ -42,7 +44,7 @@ func TestLoadWeeklySeason_Rosters_Integration(ctx context.Context, t *testing.T)
 	year := 2024
 	rows, err := LoadWeeklySeason(ctx, year)
 	if err != nil {
-		if strings.Contains(err.Error(), "HTTP error 404") || strings.Contains(err.Error(), "404 Not Found") {
+		if errors.Is(err, errs.ErrNotFound) {
 			t.Logf("season-scoped weekly_rosters for %d not found; falling back to base", year)
 			rows, err = LoadWeekly(ctx)
 			if err != nil {
@@ -0,0 +1,305 @@
+// Package rowmap decodes normalized dataset rows (map[string]any, as produced
+// by parse.Auto/parse.Stream) into typed structs and back, driven by struct tags.
+//
+// The `json` tag names the upstream column. An optional `alias` tag lists
+// alternate column names (comma-separated) tried in order when the primary
+// column is missing or empty:
+//
+//	FullName string `json:"display_name" alias:"player_name,name_full"`
+//
+// Supported field kinds: string, bool, all int/uint/float kinds, and
+// time.Time (from "2006-01-02", RFC 3339, or "2006-01-02 15:04:05" strings).
+// Missing, empty, "NA" or malformed values leave the field at its zero value.
+package rowmap
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Decode maps a normalized row into a new T.
+func Decode[T any](row map[string]any) T {
+	var v T
+	DecodeInto(row, &v)
+	return v
+}
+
+// DecodeInto fills the struct pointed to by dst from row. Non-struct
+// destinations are left untouched.
+func DecodeInto(row map[string]any, dst any) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rv = rv.Elem()
+	for _, f := range fieldsOf(rv.Type()) {
+		for _, col := range f.columns {
+			raw, ok := row[col]
+			if !ok || raw == nil {
+				continue
+			}
+			if set(rv.Field(f.index), raw) {
+				break
+			}
+		}
+	}
+}
+
+// Encode converts a tagged struct (or pointer to one) into a row map keyed by
+// each field's primary (`json`) column name.
+func Encode(v any) map[string]any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := fieldsOf(rv.Type())
+	out := make(map[string]any, len(fields))
+	for _, f := range fields {
+		out[f.columns[0]] = rv.Field(f.index).Interface()
+	}
+	return out
+}
+
+// Columns returns the primary column names for T's fields, in field order.
+func Columns[T any]() []string {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := fieldsOf(t)
+	out := make([]string, len(fields))
+	for i, f := range fields {
+		out[i] = f.columns[0]
+	}
+	return out
+}
+
+// HasAnyColumn reports whether row carries at least one of T's columns
+// (primary names or aliases). It is always true for non-struct T.
+func HasAnyColumn[T any](row map[string]any) bool {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	fields := fieldsOf(t)
+	if len(fields) == 0 {
+		return true
+	}
+	for _, f := range fields {
+		for _, c := range f.columns {
+			if _, ok := row[c]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ---- field plans ----
+
+type field struct {
+	index   int
+	columns []string // primary column first, then aliases
+}
+
+var plans sync.Map // reflect.Type -> []field
+
+func fieldsOf(t reflect.Type) []field {
+	if p, ok := plans.Load(t); ok {
+		return p.([]field)
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		cols := []string{name}
+		if a := sf.Tag.Get("alias"); a != "" {
+			for _, alias := range strings.Split(a, ",") {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					cols = append(cols, alias)
+				}
+			}
+		}
+		fields = append(fields, field{index: i, columns: cols})
+	}
+	p, _ := plans.LoadOrStore(t, fields)
+	return p.([]field)
+}
+
+// ---- conversions ----
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// set converts raw into dst and reports whether a usable value was found.
+func set(dst reflect.Value, raw any) bool {
+	if dst.Type() == timeType {
+		t, ok := toTime(raw)
+		if ok {
+			dst.Set(reflect.ValueOf(t))
+		}
+		return ok
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		s, ok := toString(raw)
+		if ok {
+			dst.SetString(s)
+		}
+		return ok
+	case reflect.Bool:
+		b, ok := toBool(raw)
+		if ok {
+			dst.SetBool(b)
+		}
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := toFloat(raw)
+		if ok {
+			dst.SetInt(int64(f))
+		}
+		return ok
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := toFloat(raw)
+		if ok && f >= 0 {
+			dst.SetUint(uint64(f))
+		}
+		return ok
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat(raw)
+		if ok {
+			dst.SetFloat(f)
+		}
+		return ok
+	default:
+		return false
+	}
+}
+
+// isNA reports upstream "missing" markers.
+func isNA(s string) bool {
+	switch s {
+	case "", "NA", "NaN", "nan", "null", "NULL", "None":
+		return true
+	}
+	return false
+}
+
+func toString(raw any) (string, bool) {
+	switch t := raw.(type) {
+	case string:
+		s := strings.TrimSpace(t)
+		return s, !isNA(s)
+	case []byte:
+		s := strings.TrimSpace(string(t))
+		return s, !isNA(s)
+	case int:
+		return strconv.Itoa(t), true
+	case int32:
+		return strconv.FormatInt(int64(t), 10), true
+	case int64:
+		return strconv.FormatInt(t, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(t), true
+	case time.Time:
+		return t.Format(time.RFC3339), !t.IsZero()
+	default:
+		return "", false
+	}
+}
+
+func toFloat(raw any) (float64, bool) {
+	switch t := raw.(type) {
+	case int:
+		return float64(t), true
+	case int32:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case float32:
+		return float64(t), true
+	case float64:
+		return t, !math.IsNaN(t)
+	case bool:
+		if t {
+			return 1, true
+		}
+		return 0, true
+	case string, []byte:
+		s, ok := toString(t)
+		if !ok {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil && !math.IsNaN(f)
+	default:
+		return 0, false
+	}
+}
+
+func toBool(raw any) (bool, bool) {
+	switch t := raw.(type) {
+	case bool:
+		return t, true
+	case string, []byte:
+		s, ok := toString(t)
+		if !ok {
+			return false, false
+		}
+		switch strings.ToLower(s) {
+		case "1", "true", "t", "yes", "y":
+			return true, true
+		case "0", "false", "f", "no", "n":
+			return false, true
+		}
+		return false, false
+	default:
+		f, ok := toFloat(raw)
+		return f != 0, ok
+	}
+}
+
+var timeLayouts = []string{
+	"2006-01-02",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 MST",
+}
+
+func toTime(raw any) (time.Time, bool) {
+	switch t := raw.(type) {
+	case time.Time:
+		return t, !t.IsZero()
+	case string, []byte:
+		s, ok := toString(t)
+		if !ok {
+			return time.Time{}, false
+		}
+		for _, layout := range timeLayouts {
+			if tm, err := time.Parse(layout, s); err == nil {
+				return tm, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,95 @@
+package rowmap
+
+import (
+	"testing"
+	"time"
+)
+
+type sample struct {
+	Name    string    `json:"display_name" alias:"player_name,name_full"`
+	Season  int       `json:"season"`
+	Pct     float64   `json:"snap_pct"`
+	Active  bool      `json:"active"`
+	Born    time.Time `json:"birth_date"`
+	Updated time.Time `json:"updated_at"`
+	ESPNID  string    `json:"espn_id"`
+	Skip    string    `json:"-"`
+	hidden  int
+}
+
+func TestDecode_TagsAliasesAndConversions(t *testing.T) {
+	got := Decode[sample](map[string]any{
+		"display_name": "NA", // missing marker falls through to aliases
+		"name_full":    "Patrick Mahomes",
+		"season":       "2024.0",
+		"snap_pct":     int64(1),
+		"active":       "TRUE",
+		"birth_date":   "1995-09-17",
+		"updated_at":   "2024-09-05T20:20:00Z",
+		"espn_id":      float64(3139477), // numeric IDs from Parquet
+		"Skip":         "x",
+	})
+
+	if got.Name != "Patrick Mahomes" {
+		t.Errorf("Name = %q", got.Name)
+	}
+	if got.Season != 2024 || got.Pct != 1 || !got.Active {
+		t.Errorf("numeric/bool fields = %+v", got)
+	}
+	if want := time.Date(1995, 9, 17, 0, 0, 0, 0, time.UTC); !got.Born.Equal(want) {
+		t.Errorf("Born = %v, want %v", got.Born, want)
+	}
+	if got.Updated.Hour() != 20 {
+		t.Errorf("Updated = %v", got.Updated)
+	}
+	if got.ESPNID != "3139477" {
+		t.Errorf("ESPNID = %q", got.ESPNID)
+	}
+	if got.Skip != "" {
+		t.Errorf("json:\"-\" field was decoded: %q", got.Skip)
+	}
+}
+
+func TestDecode_MalformedLeavesZero(t *testing.T) {
+	got := Decode[sample](map[string]any{"season": "abc", "active": "maybe", "birth_date": "soon"})
+	if got.Season != 0 || got.Active || !got.Born.IsZero() {
+		t.Fatalf("expected zero values, got %+v", got)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	in := sample{Name: "Josh Allen", Season: 2023, Pct: 0.98, Active: true}
+	m := Encode(in)
+	if m["display_name"] != "Josh Allen" || m["season"] != 2023 {
+		t.Fatalf("Encode = %v", m)
+	}
+	if _, ok := m["Skip"]; ok {
+		t.Fatalf("Encode included json:\"-\" field")
+	}
+	if out := Decode[sample](m); out.Name != in.Name || out.Season != in.Season || out.Pct != in.Pct || !out.Active {
+		t.Fatalf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	v1 := func() string {
+		type row struct {
+			Season int    `json:"season"`
+			Team   string `json:"team"`
+		}
+		return SchemaVersion[row]()
+	}
+	v2 := func() string {
+		type row struct { // same name, renamed column
+			Season int    `json:"season"`
+			Team   string `json:"team_abbr"`
+		}
+		return SchemaVersion[row]()
+	}
+	if v1() != v1() {
+		t.Fatal("SchemaVersion is not stable")
+	}
+	if v1() == v2() {
+		t.Fatal("a tag change did not change SchemaVersion")
+	}
+}
@@ -0,0 +1,69 @@
+package rowmap
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Versioned lets a model bump its schema version by hand, e.g. when its
+// FromMap logic changes without the struct itself changing.
+type Versioned interface {
+	SchemaVersion() int
+}
+
+var versions sync.Map // reflect.Type -> string
+
+// SchemaVersion fingerprints T's shape: field names, types and tags,
+// recursively, plus T's own SchemaVersion when it implements Versioned.
+// Any change to the model struct yields a different value, so caches keyed
+// by it are invalidated automatically.
+func SchemaVersion[T any]() string {
+	t := reflect.TypeFor[T]()
+	if v, ok := versions.Load(t); ok {
+		return v.(string)
+	}
+	var b strings.Builder
+	describe(&b, t, map[reflect.Type]bool{})
+	var zero T
+	if v, ok := any(zero).(Versioned); ok {
+		fmt.Fprintf(&b, "#v%d", v.SchemaVersion())
+	}
+	sum := sha1.Sum([]byte(b.String()))
+	v, _ := versions.LoadOrStore(t, hex.EncodeToString(sum[:8]))
+	return v.(string)
+}
+
+func describe(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		fmt.Fprintf(b, "%s(", t.Kind())
+		describe(b, t.Elem(), seen)
+		b.WriteString(")")
+	case reflect.Map:
+		b.WriteString("map(")
+		describe(b, t.Key(), seen)
+		b.WriteString(",")
+		describe(b, t.Elem(), seen)
+		b.WriteString(")")
+	case reflect.Struct:
+		if seen[t] || t == timeType {
+			b.WriteString(t.String())
+			return
+		}
+		seen[t] = true
+		fmt.Fprintf(b, "%s{", t.String())
+		for i := range t.NumField() {
+			f := t.Field(i)
+			fmt.Fprintf(b, "%s %q ", f.Name, f.Tag)
+			describe(b, f.Type, seen)
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(t.String())
+	}
+}
@@ -0,0 +1,31 @@
+package schedules
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+)
+
+// NOTE: schedules live in nflverse/nfldata as a single all-seasons file
+// (data/games.csv); there are no per-season assets.
+var src = datasets.Source{Repo: "nflverse/nfldata", Base: "data/games", Key: datasets.Schedules}
+
+// All seasons (1999–present, including scheduled but unplayed games)
+func Load(ctx context.Context) ([]Game, error) {
+	return datasets.LoadDatasetAs[Game](ctx, src, 0, FromMap)
+}
+
+// LoadSeason loads the combined file and keeps one season's games.
+func LoadSeason(ctx context.Context, season int) ([]Game, error) {
+	games, err := Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := games[:0]
+	for _, g := range games {
+		if g.Season == season {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
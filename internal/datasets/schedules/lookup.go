@@ -0,0 +1,83 @@
+package schedules
+
+import (
+	"strings"
+	"time"
+)
+
+// ByID returns the game with the given nflverse game_id (e.g., "2024_01_BAL_KC").
+func ByID(games []Game, gameID string) (Game, bool) {
+	for _, g := range games {
+		if g.GameID == gameID {
+			return g, true
+		}
+	}
+	return Game{}, false
+}
+
+// ForTeamWeek returns the game team played (home or away) in season/week.
+// Team matching is case-insensitive. ok is false on bye weeks.
+func ForTeamWeek(games []Game, season int, team string, week int) (Game, bool) {
+	for _, g := range games {
+		if g.Season != season || g.Week != week {
+			continue
+		}
+		if strings.EqualFold(g.HomeTeam, team) || strings.EqualFold(g.AwayTeam, team) {
+			return g, true
+		}
+	}
+	return Game{}, false
+}
+
+// Between returns games whose Gameday falls within [from, to] (by calendar
+// date, inclusive), in input order.
+func Between(games []Game, from, to time.Time) []Game {
+	lo, hi := dateOnly(from), dateOnly(to)
+	var out []Game
+	for _, g := range games {
+		d := g.Date()
+		if d.IsZero() || d.Before(lo) || d.After(hi) {
+			continue
+		}
+		out = append(out, g)
+	}
+	return out
+}
+
+// dateOnly truncates t to midnight UTC of its own calendar date.
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// CurrentWeek returns the week of season's earliest game on or after now's
+// date. It returns 1 when season has no games and the final week when every
+// game is already in the past.
+func CurrentWeek(games []Game, season int, now time.Time) int {
+	today := dateOnly(now)
+	week, last := 0, 0
+	var next time.Time
+	for _, g := range games {
+		if g.Season != season {
+			continue
+		}
+		if g.Week > last {
+			last = g.Week
+		}
+		d := g.Date()
+		if d.IsZero() || d.Before(today) {
+			continue
+		}
+		if week == 0 || d.Before(next) || (d.Equal(next) && g.Week < week) {
+			week, next = g.Week, d
+		}
+	}
+	switch {
+	case week > 0:
+		return week
+	case last > 0:
+		return last
+	default:
+		return 1
+	}
+}
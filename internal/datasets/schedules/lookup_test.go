@@ -0,0 +1,63 @@
+package schedules
+
+import (
+	"testing"
+	"time"
+)
+
+var fixture = []Game{
+	FromMap(map[string]any{"game_id": "2024_01_BAL_KC", "season": "2024", "week": "1", "gameday": "2024-09-05", "away_team": "BAL", "home_team": "KC", "away_score": "20", "home_score": "27"}),
+	FromMap(map[string]any{"game_id": "2024_01_GB_PHI", "season": "2024", "week": "1", "gameday": "2024-09-06", "away_team": "GB", "home_team": "PHI"}),
+	FromMap(map[string]any{"game_id": "2024_02_CIN_KC", "season": "2024", "week": "2", "gameday": "2024-09-15", "away_team": "CIN", "home_team": "KC", "away_score": "NA", "home_score": "NA"}),
+}
+
+func TestByID(t *testing.T) {
+	g, ok := ByID(fixture, "2024_01_BAL_KC")
+	if !ok || g.HomeScore != 27 || !g.Played() {
+		t.Fatalf("ByID = %+v, %v", g, ok)
+	}
+	if _, ok := ByID(fixture, "nope"); ok {
+		t.Fatal("ByID found a missing game")
+	}
+}
+
+func TestForTeamWeek(t *testing.T) {
+	g, ok := ForTeamWeek(fixture, 2024, "bal", 1)
+	if !ok || g.GameID != "2024_01_BAL_KC" {
+		t.Fatalf("away lookup = %+v, %v", g, ok)
+	}
+	g, ok = ForTeamWeek(fixture, 2024, "KC", 2)
+	if !ok || g.GameID != "2024_02_CIN_KC" || g.Played() {
+		t.Fatalf("home lookup = %+v, %v", g, ok)
+	}
+	if _, ok := ForTeamWeek(fixture, 2024, "BAL", 2); ok {
+		t.Fatal("expected bye/no game")
+	}
+}
+
+func TestBetween(t *testing.T) {
+	from := time.Date(2024, 9, 6, 23, 0, 0, 0, time.UTC) // time of day is ignored
+	to := time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)
+	got := Between(fixture, from, to)
+	if len(got) != 2 || got[0].GameID != "2024_01_GB_PHI" || got[1].GameID != "2024_02_CIN_KC" {
+		t.Fatalf("Between = %v", got)
+	}
+}
+
+func TestCurrentWeek(t *testing.T) {
+	at := func(s string) time.Time { d, _ := time.Parse("2006-01-02", s); return d }
+	cases := map[string]int{
+		"2024-08-01": 1, // preseason: earliest upcoming game
+		"2024-09-06": 1, // game day counts as current
+		"2024-09-10": 2,
+		"2025-01-01": 2, // past the last game: final week
+	}
+	for day, want := range cases {
+		if got := CurrentWeek(fixture, 2024, at(day)); got != want {
+			t.Errorf("CurrentWeek(%s) = %d, want %d", day, got, want)
+		}
+	}
+	if got := CurrentWeek(fixture, 2030, at("2030-09-10")); got != 1 {
+		t.Errorf("CurrentWeek(no games) = %d, want 1", got)
+	}
+}
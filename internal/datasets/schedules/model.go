@@ -0,0 +1,83 @@
+package schedules
+
+import (
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets/rowmap"
+)
+
+// Game models a single row from the nflverse schedules/games dataset
+// (nflverse/nfldata games.csv). JSON tags mirror dataset column names.
+// Unplayed games have HomeScore/AwayScore/Result == 0 and Played() == false.
+// Data dictionary: https://nflreadr.nflverse.com/articles/dictionary_schedules.html
+type Game struct {
+	GameID     string `json:"game_id"`
+	Season     int    `json:"season"`
+	GameType   string `json:"game_type"` // REG, WC, DIV, CON, SB
+	Week       int    `json:"week"`
+	Gameday    string `json:"gameday"`  // YYYY-MM-DD (local)
+	Weekday    string `json:"weekday"`  // e.g., Sunday
+	Gametime   string `json:"gametime"` // HH:MM, US Eastern
+	AwayTeam   string `json:"away_team"`
+	AwayScore  int    `json:"away_score"`
+	HomeTeam   string `json:"home_team"`
+	HomeScore  int    `json:"home_score"`
+	Location   string `json:"location"` // Home or Neutral
+	Result     int    `json:"result"`   // home_score - away_score
+	Total      int    `json:"total"`    // home_score + away_score
+	Overtime   bool   `json:"overtime"`
+	OldGameID  string `json:"old_game_id"`
+	GSIS       string `json:"gsis"`
+	NFLDetail  string `json:"nfl_detail_id"`
+	PFR        string `json:"pfr"`
+	PFF        string `json:"pff"`
+	ESPN       string `json:"espn"`
+	FTN        string `json:"ftn"`
+	AwayRest   int    `json:"away_rest"`
+	HomeRest   int    `json:"home_rest"`
+	DivGame    bool   `json:"div_game"`
+	Roof       string `json:"roof"`    // dome, outdoors, closed, open
+	Surface    string `json:"surface"` // grass, fieldturf, ...
+	Temp       int    `json:"temp"`
+	Wind       int    `json:"wind"`
+	StadiumID  string `json:"stadium_id"`
+	Stadium    string `json:"stadium"`
+	Referee    string `json:"referee"`
+	AwayQBID   string `json:"away_qb_id"`
+	HomeQBID   string `json:"home_qb_id"`
+	AwayQBName string `json:"away_qb_name"`
+	HomeQBName string `json:"home_qb_name"`
+	AwayCoach  string `json:"away_coach"`
+	HomeCoach  string `json:"home_coach"`
+
+	// betting lines (home perspective)
+	AwayMoneyline int     `json:"away_moneyline"`
+	HomeMoneyline int     `json:"home_moneyline"`
+	SpreadLine    float64 `json:"spread_line"`
+	AwaySpreadOdd int     `json:"away_spread_odds"`
+	HomeSpreadOdd int     `json:"home_spread_odds"`
+	TotalLine     float64 `json:"total_line"`
+	UnderOdds     int     `json:"under_odds"`
+	OverOdds      int     `json:"over_odds"`
+}
+
+// Date parses Gameday; it returns the zero time when Gameday is empty or malformed.
+func (g Game) Date() time.Time {
+	t, _ := time.Parse("2006-01-02", g.Gameday)
+	return t
+}
+
+// Played reports whether the game has a final score.
+func (g Game) Played() bool {
+	return g.HomeScore != 0 || g.AwayScore != 0 || g.Result != 0 || g.Total != 0
+}
+
+// FromMap converts a generic row into a typed Game.
+func FromMap(row map[string]any) Game {
+	return rowmap.Decode[Game](row)
+}
+
+// ToMap converts a Game back to dataset-style keys.
+func (g Game) ToMap() map[string]any {
+	return rowmap.Encode(g)
+}
@@ -0,0 +1,113 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// ErrSeasonUnavailable is returned (wrapped) when a requested season is
+// outside what a dataset publishes.
+var ErrSeasonUnavailable = errs.ErrSeasonUnavailable
+
+// firstSeason is the earliest season each season-scoped dataset publishes
+// (per nflreadr). Datasets missing here are not season-scoped.
+var firstSeason = map[Key]int{
+	PlayByPlay:        1999,
+	PlayerStats:       1999,
+	PlayerStatsWeekly: 1999,
+	TeamStatsWeekly:   1999,
+	Schedules:         1999,
+	Rosters:           1920,
+	RostersWeekly:     2002,
+	SnapCounts:        2012,
+	Injuries:          2009,
+	DepthCharts:       2001,
+}
+
+// publishedAhead lists datasets that publish seasons beyond the current one
+// (schedules are released in the spring, before the season rolls over).
+var publishedAhead = map[Key]int{Schedules: 1}
+
+var (
+	discoveredMu sync.RWMutex
+	discovered   = map[Key][]int{} // from RefreshSeasons; overrides firstSeason
+)
+
+// AvailableSeasons returns the seasons key publishes, ascending. Unless
+// RefreshSeasons has recorded the exact list, this is firstSeason..cur
+// (plus publishedAhead).
+func AvailableSeasons(key Key, cur int) ([]int, error) {
+	discoveredMu.RLock()
+	got, ok := discovered[key]
+	discoveredMu.RUnlock()
+	if ok {
+		return slices.Clone(got), nil
+	}
+	first, ok := firstSeason[key]
+	if !ok {
+		return nil, errs.Wrap(string(key), "", errors.New("dataset is not season-scoped"))
+	}
+	last := cur + publishedAhead[key]
+	out := make([]int, 0, max(last-first+1, 0))
+	for yr := first; yr <= last; yr++ {
+		out = append(out, yr)
+	}
+	return out, nil
+}
+
+// CheckSeasons returns an ErrSeasonUnavailable error naming the first season
+// in seasons that key does not publish.
+func CheckSeasons(key Key, seasons []int, cur int) error {
+	avail, err := AvailableSeasons(key, cur)
+	if err != nil {
+		return err
+	}
+	for _, s := range seasons {
+		if _, ok := slices.BinarySearch(avail, s); !ok {
+			if len(avail) == 0 {
+				return errs.Wrap(string(key), "", fmt.Errorf("season %d: %w (none published)", s, ErrSeasonUnavailable))
+			}
+			return errs.Wrap(string(key), "", fmt.Errorf("season %d: %w (available %d-%d)", s, ErrSeasonUnavailable, avail[0], avail[len(avail)-1]))
+		}
+	}
+	return nil
+}
+
+// RefreshSeasons re-reads key's seasons from its release asset listing and
+// records them for AvailableSeasons/CheckSeasons.
+func RefreshSeasons(ctx context.Context, key Key) ([]int, error) {
+	assets, err := ListAssets(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	a, _ := ResolveRelease(key, 0, download.FormatParquet)
+	stem := strings.TrimSuffix(a.Name, download.FormatParquet.Ext()) + "_"
+
+	var seasons []int
+	for _, x := range assets {
+		name, ok := strings.CutPrefix(x.Name, stem)
+		if !ok || len(name) < 4 {
+			continue
+		}
+		yr, err := strconv.Atoi(name[:4])
+		if err != nil || (len(name) > 4 && name[4] != '.') {
+			continue
+		}
+		seasons = append(seasons, yr)
+	}
+	slices.Sort(seasons)
+	seasons = slices.Compact(seasons)
+
+	discoveredMu.Lock()
+	discovered[key] = seasons
+	discoveredMu.Unlock()
+	return slices.Clone(seasons), nil
+}
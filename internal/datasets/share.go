@@ -0,0 +1,70 @@
+package datasets
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/flight"
+)
+
+// loadKey identifies one dataset load: the same asset, resolved the same
+// way, fetched through the same cache with the same checks and decoded into
+// the same model. The dataset key and row type stand for the mapper, which
+// is fixed for each (see LoadDatasetAs).
+type loadKey struct {
+	repo, base   string
+	dataset      Key
+	season       int
+	prefer       download.Format
+	resolver     string
+	cache        download.Cache
+	offline      bool
+	staleIfError bool
+	verify       bool
+	row          reflect.Type
+}
+
+type loaded struct {
+	rows  any // []T
+	asset Asset
+}
+
+// loads coalesces concurrent identical loads so they share one download and
+// one parse.
+var loads flight.Group[loadKey, loaded]
+
+func newLoadKey[T any](ctx context.Context, src Source, season int) loadKey {
+	return loadKey{
+		repo:         src.Repo,
+		base:         src.Base,
+		dataset:      src.Key,
+		season:       season,
+		prefer:       configFrom(ctx).Prefer,
+		resolver:     fmt.Sprintf("%T%v", resolverFrom(ctx), resolverFrom(ctx)),
+		cache:        clientFrom(ctx).Cache(),
+		offline:      clientFrom(ctx).Offline(),
+		staleIfError: clientFrom(ctx).StaleIfError(),
+		verify:       configFrom(ctx).VerifyChecksums,
+		row:          reflect.TypeFor[T](),
+	}
+}
+
+// shareLoad runs load once per key among concurrent callers. Each caller
+// gets its own copy of the rows, so filtering in place is safe. Loads
+// through a cache that can't be a map key aren't shared.
+func shareLoad[T any](ctx context.Context, k loadKey, load func(context.Context) ([]T, Asset, error)) ([]T, Asset, error) {
+	if k.cache != nil && !reflect.TypeOf(k.cache).Comparable() {
+		return load(ctx)
+	}
+	v, _, err := loads.Do(ctx, k, func(ctx context.Context) (loaded, error) {
+		rows, asset, err := load(ctx)
+		return loaded{rows, asset}, err
+	})
+	if err != nil {
+		return nil, Asset{}, err
+	}
+	return slices.Clone(v.rows.([]T)), v.asset, nil
+}
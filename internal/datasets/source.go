@@ -2,10 +2,17 @@ package datasets
 
 import (
 	"context"
+	"errors"
 	"io"
+	"iter"
+	"maps"
+	pathpkg "path"
+	"slices"
 	"strings"
 
+	"github.com/tyler180/nfl-data-go/internal/datasets/rowmap"
 	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
 	"github.com/tyler180/nfl-data-go/internal/parse"
 	"github.com/tyler180/nfl-data-go/internal/source"
 )
@@ -23,6 +30,24 @@ import (
 type Source struct {
 	Repo string
 	Base string
+	Key  Key // dataset key reported in errors; Base is used when empty
+}
+
+// name identifies src in errors.
+func (s Source) name() string {
+	if s.Key != "" {
+		return string(s.Key)
+	}
+	return s.Base
+}
+
+// label names src in the cache index: its key, or the last element of
+// Base for unkeyed sources.
+func (s Source) label() string {
+	if s.Key != "" {
+		return string(s.Key)
+	}
+	return pathLabel(s.Base)
 }
 
 // SeasonPath returns base or base_YYYY when season > 0.
@@ -33,93 +58,338 @@ func SeasonPath(base string, season int) string {
 	return base
 }
 
+// Asset describes the upstream file a load actually resolved to.
+type Asset struct {
+	URL      string
+	Format   download.Format
+	Season   int    // 0 when the base (all seasons) asset was used
+	Encoding string // response Content-Encoding, if any
+	ETag     string // upstream ETag, if any
+
+	Status download.CacheStatus // how the body was served (cache hit, 304, download)
+}
+
 // LoadFromSourceAs downloads (Repo, Base[_season]) and maps rows using mapper.
-// CSV vs Parquet is auto-detected by parse.Auto.
-// If a season-specific asset 404s, this automatically falls back to the base asset.
+// The file extension is chosen from the ctx config's Prefer (see WithConfig),
+// falling back to the other formats when the preferred one 404s. If a season-specific asset
+// 404s in every format, this automatically falls back to the base asset.
 func LoadFromSourceAs[T any](ctx context.Context, src Source, season int, mapper func(map[string]any) T) ([]T, error) {
-	dl := download.New() // default client (30s timeout, no cache unless you add options)
+	out, _, err := LoadFromSourceWithAsset(ctx, src, season, mapper)
+	return out, err
+}
 
-	// Try season-scoped first when requested.
-	if season > 0 {
-		p := SeasonPath(src.Base, season)
-		url := source.RawGitHubURL(src.Repo, p)
-		rc, _, err := dl.Fetch(ctx, url)
-		if err == nil {
-			defer rc.Close()
-			b, rerr := io.ReadAll(rc)
-			if rerr != nil {
-				return nil, rerr
-			}
-			rows, rerr := parse.Auto(b, url)
-			if rerr != nil {
-				return nil, rerr
-			}
-			out := make([]T, 0, len(rows))
-			for _, r := range rows {
-				out = append(out, mapper(r))
+// LoadFromSourceWithAsset is LoadFromSourceAs that also reports which asset
+// (URL, format, season) was used.
+func LoadFromSourceWithAsset[T any](ctx context.Context, src Source, season int, mapper func(map[string]any) T) ([]T, Asset, error) {
+	return loadSource(ctx, src, season, mapper, false)
+}
+
+// LoadDatasetAs is LoadFromSourceAs for the dataset packages' loaders, whose
+// fromMap is their package's FromMap: the same function for every load of
+// src.Key into T. That lets concurrent identical loads share one download
+// and one parse, and warm loads reuse the parsed rows (see parsed.go).
+// Sources without a Key are loaded like LoadFromSourceAs.
+func LoadDatasetAs[T any](ctx context.Context, src Source, season int, fromMap func(map[string]any) T) ([]T, error) {
+	if src.Key == "" {
+		return LoadFromSourceAs(ctx, src, season, fromMap)
+	}
+	out, _, err := shareLoad(ctx, newLoadKey[T](ctx, src, season), func(ctx context.Context) ([]T, Asset, error) {
+		return loadSource(ctx, src, season, fromMap, true)
+	})
+	return out, err
+}
+
+// loadSource loads and maps src's rows, going through the parsed-rows tier
+// when parsed is set: rows cached for the asset the download cache would
+// serve are returned without opening it.
+func loadSource[T any](ctx context.Context, src Source, season int, mapper func(map[string]any) T, parsed bool) ([]T, Asset, error) {
+	dl := clientFrom(ctx)
+	prefer := configFrom(ctx).Prefer
+
+	if parsed {
+		// A parsed hit for the asset the cache would serve skips the body.
+		if asset, ok := cachedAsset(ctx, dl, src, season); ok {
+			if out, ok := loadParsed[T](ctx, src, asset); ok {
+				return out, asset, nil
 			}
-			return out, nil
 		}
-		// Fallback to base if the season file isn't published.
-		if !shouldFallbackToBase(err) {
-			return nil, err
+	}
+	rc, asset, err := openSource(ctx, dl, resolverFrom(ctx), src, season, prefer)
+	if err != nil {
+		return nil, Asset{}, errs.Wrap(src.name(), "", err)
+	}
+	defer rc.Close()
+	if parsed {
+		// A revalidated (304) asset may still have its parsed rows.
+		if out, ok := loadParsed[T](ctx, src, asset); ok {
+			return out, asset, nil
 		}
 	}
+	out, err := collectAs(streamAs(ctx, rc, asset, mapper))
+	if err != nil {
+		return nil, Asset{}, errs.Wrap(src.name(), asset.URL, err)
+	}
+	if parsed {
+		storeParsed(ctx, src, asset, out)
+	}
+	return out, asset, nil
+}
+
+// StreamFromSourceAs is the streaming form of LoadFromSourceAs: rows are
+// decoded and mapped one at a time straight from the download (or cache)
+// stream instead of being materialized first. Iteration stops at the first
+// error, including ctx cancellation mid-stream; breaking out of the loop
+// closes the underlying body.
+//
+//	for r, err := range datasets.StreamFromSourceAs(ctx, src, 2024, FromMap) {
+//		if err != nil { return err }
+//		...
+//	}
+func StreamFromSourceAs[T any](ctx context.Context, src Source, season int, mapper func(map[string]any) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		dl := clientFrom(ctx)
+		rc, asset, err := openSource(ctx, dl, resolverFrom(ctx), src, season, configFrom(ctx).Prefer)
+		if err != nil {
+			var zero T
+			yield(zero, errs.Wrap(src.name(), "", err))
+			return
+		}
+		defer rc.Close()
+		wrapErrs(streamAs(ctx, rc, asset, mapper), src.name(), asset.URL)(yield)
+	}
+}
 
-	// Base (all seasons)
-	url := source.RawGitHubURL(src.Repo, src.Base)
-	rc, _, err := dl.Fetch(ctx, url)
+// LoadFromPathAs is a convenience for direct (repo, path) loads without a season param.
+// Paths without an extension are resolved using the preferred format.
+func LoadFromPathAs[T any](ctx context.Context, repo, path string, mapper func(map[string]any) T) ([]T, error) {
+	ctx = download.WithAsset(ctx, pathLabel(path), 0)
+	dl := clientFrom(ctx)
+	rc, asset, err := openAsset(ctx, dl, resolverFrom(ctx), repo, path, configFrom(ctx).Prefer)
 	if err != nil {
-		return nil, err
+		return nil, errs.Wrap(path, "", err)
 	}
 	defer rc.Close()
-	b, err := io.ReadAll(rc)
+	out, err := collectAs(streamAs(ctx, rc, asset, mapper))
+	return out, errs.Wrap(path, asset.URL, err)
+}
+
+// LoadURLAs loads and maps the rows of a fixed url (a mirror or override
+// rather than a resolved path), recorded in the cache index under src's
+// label and season. The format is detected from the body.
+func LoadURLAs[T any](ctx context.Context, src Source, season int, url string, mapper func(map[string]any) T) ([]T, error) {
+	ctx = download.WithAsset(ctx, src.label(), season)
+	dl := clientFrom(ctx)
+	rc, meta, err := dl.Fetch(withDigest(ctx, dl, url), url)
 	if err != nil {
-		return nil, err
+		return nil, errs.Wrap(src.name(), url, err)
 	}
-	rows, err := parse.Auto(b, url)
+	defer rc.Close()
+	f, _ := download.FormatOfPath(url)
+	asset := Asset{URL: url, Format: f, Season: season, Encoding: meta.ContentEncoding, ETag: meta.ETag, Status: meta.Status}
+	out, err := collectAs(streamAs(ctx, rc, asset, mapper))
 	if err != nil {
-		return nil, err
-	}
-	out := make([]T, 0, len(rows))
-	for _, r := range rows {
-		out = append(out, mapper(r))
+		return nil, errs.Wrap(src.name(), url, err)
 	}
 	return out, nil
 }
 
-// LoadFromPathAs is a convenience for direct (repo, path) loads without a season param.
-func LoadFromPathAs[T any](ctx context.Context, repo, path string, mapper func(map[string]any) T) ([]T, error) {
-	dl := download.New()
-	url := source.RawGitHubURL(repo, path)
-	rc, _, err := dl.Fetch(ctx, url)
+// StreamFromPathAs is the streaming form of LoadFromPathAs.
+func StreamFromPathAs[T any](ctx context.Context, repo, path string, mapper func(map[string]any) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		dl := clientFrom(ctx)
+		rc, asset, err := openAsset(download.WithAsset(ctx, pathLabel(path), 0), dl, resolverFrom(ctx), repo, path, configFrom(ctx).Prefer)
+		if err != nil {
+			var zero T
+			yield(zero, errs.Wrap(path, "", err))
+			return
+		}
+		defer rc.Close()
+		wrapErrs(streamAs(ctx, rc, asset, mapper), path, asset.URL)(yield)
+	}
+}
+
+// LoadRawFromSource returns the bytes of the asset (Repo, Base[_season])
+// resolves to, fetched the way LoadFromSourceAs fetches it (the ctx client
+// and resolver, format preference and season fallback), and that asset.
+func LoadRawFromSource(ctx context.Context, src Source, season int) ([]byte, Asset, error) {
+	rc, asset, err := openSource(ctx, clientFrom(ctx), resolverFrom(ctx), src, season, configFrom(ctx).Prefer)
 	if err != nil {
-		return nil, err
+		return nil, Asset{}, errs.Wrap(src.name(), "", err)
 	}
 	defer rc.Close()
 	b, err := io.ReadAll(rc)
 	if err != nil {
-		return nil, err
+		return nil, Asset{}, errs.Wrap(src.name(), asset.URL, err)
 	}
-	rows, err := parse.Auto(b, url)
-	if err != nil {
-		return nil, err
+	return b, asset, nil
+}
+
+// openSource resolves (Repo, Base[_season]) to an open asset body,
+// trying the season-scoped path first and the base path on 404.
+func openSource(ctx context.Context, dl *download.Client, res source.Resolver, src Source, season int, prefer download.Format) (io.ReadCloser, Asset, error) {
+	label := src.label()
+	if season > 0 {
+		ctx := download.WithAsset(ctx, label, season)
+		rc, asset, err := openAsset(ctx, dl, res, src.Repo, SeasonPath(src.Base, season), prefer)
+		if err == nil {
+			asset.Season = season
+			return rc, asset, nil
+		}
+		// Fallback to base if the season file isn't published (or,
+		// offline, isn't cached).
+		if !missing(err) {
+			return nil, Asset{}, err
+		}
+	}
+	return openAsset(download.WithAsset(ctx, label, 0), dl, res, src.Repo, src.Base, prefer)
+}
+
+// cachedAsset reports the asset openSource would serve for (src, season)
+// from dl's cache without making a request, with its cached ETag: the first
+// asset it tries, when that entry is fresh, or offline the first one cached
+// at all. Anything else needs the network, so it reports false.
+func cachedAsset(ctx context.Context, dl *download.Client, src Source, season int) (Asset, bool) {
+	cache := dl.Cache()
+	if cache == nil {
+		return Asset{}, false
+	}
+	res, prefer := resolverFrom(ctx), configFrom(ctx).Prefer
+	paths := []string{src.Base}
+	if season > 0 {
+		paths = []string{SeasonPath(src.Base, season), src.Base}
+	}
+	for i, p := range paths {
+		for _, a := range assetCandidates(res, src.Repo, p, prefer) {
+			meta, fresh, ok := cache.Lookup(a.URL)
+			if !dl.Offline() && !(ok && fresh) {
+				return Asset{}, false
+			}
+			if !ok {
+				continue
+			}
+			a.Encoding, a.ETag, a.Status = meta.ContentEncoding, meta.ETag, download.CacheFresh
+			if !fresh {
+				a.Status = download.CacheStale
+			}
+			if season > 0 && i == 0 {
+				a.Season = season
+			}
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// pathLabel names an unkeyed asset path in the cache index: its last
+// element without extension ("files/db_playerids" → "db_playerids").
+func pathLabel(p string) string {
+	p = pathpkg.Base(p)
+	if i := strings.IndexByte(p, '.'); i > 0 {
+		p = p[:i]
+	}
+	return p
+}
+
+// openAsset fetches repo/path, with URLs built by res. When path has no known
+// extension, each format is tried in preference order and 404s (or, offline,
+// cache misses) move on to the next one.
+func openAsset(ctx context.Context, dl *download.Client, res source.Resolver, repo, path string, prefer download.Format) (io.ReadCloser, Asset, error) {
+	var lastErr error
+	for _, a := range assetCandidates(res, repo, path, prefer) {
+		rc, meta, err := dl.Fetch(withDigest(ctx, dl, a.URL), a.URL)
+		if err == nil {
+			a.Encoding, a.ETag, a.Status = meta.ContentEncoding, meta.ETag, meta.Status
+			return rc, a, nil
+		}
+		if !missing(err) {
+			return nil, Asset{}, errs.Wrap("", a.URL, err)
+		}
+		lastErr = errs.Wrap("", a.URL, err)
+	}
+	return nil, Asset{}, lastErr
+}
+
+// assetCandidates lists the assets openAsset tries for repo/path, in order:
+// path itself when it has a known extension, else path with each format's
+// extension in preference order.
+func assetCandidates(res source.Resolver, repo, path string, prefer download.Format) []Asset {
+	if f, ok := download.FormatOfPath(path); ok {
+		return []Asset{{URL: res.URL(repo, path), Format: f}}
+	}
+	var out []Asset
+	for _, f := range formatOrder(prefer) {
+		out = append(out, Asset{URL: res.URL(repo, path+f.Ext()), Format: f})
+	}
+	return out
+}
+
+// missing reports whether err means "try another path": the asset is not
+// published, or offline mode has no cached copy of it.
+func missing(err error) bool {
+	return errors.Is(err, errs.ErrNotFound) || errors.Is(err, errs.ErrNotCached)
+}
+
+// formatOrder returns the formats to try: the preferred one first, then the rest.
+func formatOrder(prefer download.Format) []download.Format {
+	switch prefer {
+	case download.FormatCSV:
+		return []download.Format{download.FormatCSV, download.FormatCSVGzip, download.FormatParquet}
+	case download.FormatCSVGzip:
+		return []download.Format{download.FormatCSVGzip, download.FormatCSV, download.FormatParquet}
+	default:
+		return []download.Format{download.FormatParquet, download.FormatCSVGzip, download.FormatCSV}
+	}
+}
+
+// streamAs parses r row by row (decompressing if needed) and maps each row,
+// checking ctx between rows. When T is a rowmap-tagged struct and the first
+// row has none of its columns, it yields an *errs.SchemaError.
+func streamAs[T any](ctx context.Context, r io.Reader, asset Asset, mapper func(map[string]any) T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		first := true
+		for row, err := range parse.Stream(r, asset.URL, asset.Encoding) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			if first && !rowmap.HasAnyColumn[T](row) {
+				yield(zero, &errs.SchemaError{URL: asset.URL, Want: rowmap.Columns[T](), Got: slices.Sorted(maps.Keys(row))})
+				return
+			}
+			first = false
+			if !yield(mapper(row), nil) {
+				return
+			}
+		}
 	}
-	out := make([]T, 0, len(rows))
-	for _, r := range rows {
-		out = append(out, mapper(r))
+}
+
+// collectAs drains a typed row iterator, stopping at the first error.
+func collectAs[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
 	}
 	return out, nil
 }
 
-// shouldFallbackToBase reports whether a season-scoped download error should retry the base asset.
-// We conservatively match common 404 phrases so callers don't need to import internal error types.
-func shouldFallbackToBase(err error) bool {
-	if err == nil {
-		return false
+// wrapErrs annotates every error from seq with the dataset name and URL.
+func wrapErrs[T any](seq iter.Seq2[T, error], name, url string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range seq {
+			if !yield(v, errs.Wrap(name, url, err)) {
+				return
+			}
+		}
 	}
-	s := err.Error()
-	return strings.Contains(s, "404") || strings.Contains(s, "not found")
 }
 
 // tiny local itoa to avoid extra deps here
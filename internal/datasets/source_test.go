@@ -1,6 +1,27 @@
 package datasets
 
-import "testing"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/datasets/rowmap"
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
 
 func TestSeasonPath(t *testing.T) {
 	if got := SeasonPath("injuries/injuries", 0); got != "injuries/injuries" {
@@ -10,3 +31,402 @@ func TestSeasonPath(t *testing.T) {
 		t.Fatalf("SeasonPath with year: got %q", got)
 	}
 }
+
+// rewriteTransport sends every request to a test server, keeping the path.
+type rewriteTransport struct{ target *url.URL }
+
+func (rt rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme, r.URL.Host = rt.target.Scheme, rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func testClient(t *testing.T, h http.Handler) *download.Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	u, _ := url.Parse(srv.URL)
+	return download.New(download.WithHTTPClient(&http.Client{Transport: rewriteTransport{u}}))
+}
+
+func TestOpenSource_FormatAndSeasonFallback(t *testing.T) {
+	var hits []string
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/injuries/injuries.csv") {
+			io.WriteString(w, "season,team\n2024,KC\n")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries"}
+	rc, asset, err := openSource(context.Background(), dl, source.RawResolver{}, src, 2024, download.FormatParquet)
+	if err != nil {
+		t.Fatalf("openSource: %v", err)
+	}
+	rc.Close()
+
+	if asset.Format != download.FormatCSV || asset.Season != 0 || !strings.HasSuffix(asset.URL, "injuries.csv") {
+		t.Fatalf("asset = %+v, want base CSV", asset)
+	}
+	want := []string{
+		"/nflverse/nflverse-data/master/injuries/injuries_2024.parquet",
+		"/nflverse/nflverse-data/master/injuries/injuries_2024.csv.gz",
+		"/nflverse/nflverse-data/master/injuries/injuries_2024.csv",
+		"/nflverse/nflverse-data/master/injuries/injuries.parquet",
+		"/nflverse/nflverse-data/master/injuries/injuries.csv.gz",
+		"/nflverse/nflverse-data/master/injuries/injuries.csv",
+	}
+	if strings.Join(hits, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("request order:\n%s\nwant:\n%s", strings.Join(hits, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestOpenAsset_PreferCSV(t *testing.T) {
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "a,b\n1,2\n")
+	}))
+	rc, asset, err := openAsset(context.Background(), dl, source.DefaultResolver, "nflverse-data", "players/players", download.FormatCSV)
+	if err != nil {
+		t.Fatalf("openAsset: %v", err)
+	}
+	rc.Close()
+	if asset.Format != download.FormatCSV {
+		t.Fatalf("format = %v, want csv", asset.Format)
+	}
+}
+
+func TestStreamAs_CancelMidStream(t *testing.T) {
+	body := "season,team\n2024,KC\n2024,BUF\n2024,DET\n"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []string
+	var gotErr error
+	seq := streamAs(ctx, strings.NewReader(body), Asset{URL: "x.csv"}, func(m map[string]any) string {
+		return m["team"].(string)
+	})
+	for team, err := range seq {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, team)
+		cancel()
+	}
+	if len(got) != 1 || got[0] != "KC" {
+		t.Fatalf("rows before cancel = %v, want [KC]", got)
+	}
+	if gotErr != context.Canceled {
+		t.Fatalf("err = %v, want context.Canceled", gotErr)
+	}
+}
+
+func TestListAssets(t *testing.T) {
+	var path string
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		io.WriteString(w, `{"assets":[{"name":"roster_weekly_2024.parquet","size":10},{"name":"roster_weekly_2024.csv","size":20}]}`)
+	}))
+	ctx := WithClient(context.Background(), dl)
+
+	assets, err := ListAssets(ctx, RostersWeekly)
+	if err != nil {
+		t.Fatalf("ListAssets: %v", err)
+	}
+	if path != "/repos/nflverse/nflverse-data/releases/tags/weekly_rosters" {
+		t.Fatalf("manifest path = %q", path)
+	}
+	if len(assets) != 2 || assets[1].Name != "roster_weekly_2024.csv" || assets[1].Tag != "weekly_rosters" {
+		t.Fatalf("assets = %+v", assets)
+	}
+
+	a, err := ResolveRelease(RostersWeekly, 2024, download.FormatParquet)
+	if err != nil || a.URL() != "https://github.com/nflverse/nflverse-data/releases/download/weekly_rosters/roster_weekly_2024.parquet" {
+		t.Fatalf("ResolveRelease = %+v, %v", a, err)
+	}
+}
+
+func TestOpenAsset_VerifyChecksums(t *testing.T) {
+	body := "season,team\n2024,KC\n"
+	digest := "sha256:" + strings.Repeat("0", 64)
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			fmt.Fprintf(w, `{"assets":[{"name":"injuries_2024.csv","digest":%q}]}`, digest)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	cfg := config.DefaultAppConfig()
+	cfg.VerifyChecksums = true
+	ctx := WithClient(context.WithValue(context.Background(), configKey{}, cfg), dl)
+	open := func() error {
+		rc, _, err := openAsset(ctx, dl, source.DefaultResolver, "nflverse-data", "injuries/injuries_2024.csv", download.FormatCSV)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		_, err = io.Copy(io.Discard, rc) // checked as the body ends
+		return err
+	}
+
+	if err := open(); !errors.Is(err, errs.ErrIntegrity) {
+		t.Fatalf("wrong digest: err = %v, want ErrIntegrity", err)
+	}
+	sum := sha256.Sum256([]byte(body))
+	digest = "sha256:" + hex.EncodeToString(sum[:])
+	if err := open(); err != nil {
+		t.Fatalf("matching digest: %v", err)
+	}
+}
+
+func TestRefreshSeasons(t *testing.T) {
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"assets":[
+			{"name":"injuries_2010.parquet"},{"name":"injuries_2010.csv"},
+			{"name":"injuries_2009.csv.gz"},{"name":"injuries.parquet"},{"name":"injuries_2012_old.csv"}]}`)
+	}))
+	t.Cleanup(func() {
+		discoveredMu.Lock()
+		delete(discovered, Injuries)
+		discoveredMu.Unlock()
+	})
+
+	got, err := RefreshSeasons(WithClient(context.Background(), dl), Injuries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != 2009 || got[1] != 2010 {
+		t.Fatalf("seasons = %v, want [2009 2010]", got)
+	}
+	if err := CheckSeasons(Injuries, []int{2010}, 2024); err != nil {
+		t.Fatalf("CheckSeasons(2010): %v", err)
+	}
+	if err := CheckSeasons(Injuries, []int{2011}, 2024); !errors.Is(err, ErrSeasonUnavailable) {
+		t.Fatalf("CheckSeasons(2011) = %v, want ErrSeasonUnavailable", err)
+	}
+}
+
+type injuryRow struct {
+	Season int    `json:"season"`
+	Team   string `json:"team"`
+}
+
+func TestLoadErrors_Typed(t *testing.T) {
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries", Key: Injuries}
+	mapper := rowmap.Decode[injuryRow]
+	load := func(h http.HandlerFunc) error {
+		ctx := WithResolver(WithClient(context.Background(), testClient(t, h)), source.RawResolver{})
+		_, err := LoadFromSourceAs(ctx, src, 0, mapper)
+		return err
+	}
+
+	err := load(http.NotFound)
+	var e *errs.Error
+	if !errors.Is(err, errs.ErrNotFound) || !errors.As(err, &e) {
+		t.Fatalf("404: err = %v, want ErrNotFound wrapped in *errs.Error", err)
+	}
+	if e.Dataset != "injuries" || !strings.HasSuffix(e.URL, "injuries.csv") {
+		t.Fatalf("404: dataset/url = %q %q", e.Dataset, e.URL)
+	}
+
+	err = load(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTooManyRequests) })
+	if !errors.Is(err, errs.ErrRateLimited) {
+		t.Fatalf("429: err = %v, want ErrRateLimited", err)
+	}
+
+	csvOnly := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, ".csv") {
+				http.NotFound(w, r)
+				return
+			}
+			io.WriteString(w, body)
+		}
+	}
+	err = load(csvOnly("season,team\n2024,KC\n2024,\"BUF\n"))
+	var pe *errs.ParseError
+	if !errors.Is(err, errs.ErrParse) || !errors.As(err, &pe) || pe.Row != 2 {
+		t.Fatalf("bad csv: err = %v, want ParseError at row 2", err)
+	}
+
+	err = load(csvOnly("foo,bar\n1,2\n"))
+	var se *errs.SchemaError
+	if !errors.Is(err, errs.ErrSchemaMismatch) || !errors.As(err, &se) || !errors.As(err, &e) || e.Dataset != "injuries" {
+		t.Fatalf("wrong columns: err = %v, want SchemaError for injuries", err)
+	}
+}
+
+func TestLoadDatasetAs_SharesConcurrentLoads(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	dl := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".csv") {
+			http.NotFound(w, r)
+			return
+		}
+		hits.Add(1)
+		<-release
+		io.WriteString(w, "season,team\n2024,KC\n2024,BUF\n")
+	}))
+	ctx := WithResolver(WithClient(context.Background(), dl), source.RawResolver{})
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries", Key: Injuries}
+	mapper := rowmap.Decode[injuryRow]
+
+	const n = 3
+	got := make([][]injuryRow, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rows, err := LoadDatasetAs(ctx, src, 0, mapper)
+			if err != nil {
+				t.Error(err)
+			}
+			got[i] = rows
+		}()
+	}
+	for loads.Waiters(newLoadKey[injuryRow](ctx, src, 0)) < n-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Fatalf("csv requests = %d, want 1", hits.Load())
+	}
+	got[0][0].Team = "changed" // callers own their rows
+	if len(got[1]) != 2 || got[1][0].Team != "KC" {
+		t.Fatalf("rows = %+v", got[1])
+	}
+}
+
+// tagCache is a cache value that can't be a map key.
+type tagCache struct {
+	download.Cache
+	tags []string
+}
+
+func TestLoadDatasetAs_UnhashableCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".csv") {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "season,team\n2024,KC\n")
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	cache := tagCache{Cache: download.NewMemCache(time.Hour), tags: []string{"x"}}
+	dl := download.New(download.WithCache(cache), download.WithHTTPClient(&http.Client{Transport: rewriteTransport{u}}))
+	ctx := WithResolver(WithClient(context.Background(), dl), source.RawResolver{})
+
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries", Key: Injuries}
+	rows, err := LoadDatasetAs(ctx, src, 0, rowmap.Decode[injuryRow])
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("rows = %+v, err = %v", rows, err)
+	}
+}
+
+func TestLoadDatasetAs_ParsedCache(t *testing.T) {
+	etag := `"v1"`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".csv") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		io.WriteString(w, "season,team\n2024,KC\n2024,BUF\n")
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	cfg := config.Resolve(
+		config.WithCacheMode(config.CacheModeFilesystem),
+		config.WithCacheDir(t.TempDir()),
+		config.WithCacheDuration(time.Nanosecond), // always revalidate the raw file
+	)
+	dl := download.New(download.WithCache(cfg.CacheBackend()), download.WithHTTPClient(&http.Client{Transport: rewriteTransport{u}}))
+	ctx := WithResolver(WithClient(WithConfig(context.Background(), cfg), dl), source.RawResolver{})
+
+	var mapped int
+	mapper := func(m map[string]any) injuryRow {
+		mapped++
+		return rowmap.Decode[injuryRow](m)
+	}
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries", Key: Injuries}
+	load := func() []injuryRow {
+		t.Helper()
+		rows, err := LoadDatasetAs(ctx, src, 0, mapper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	load()
+	if rows := load(); mapped != 2 || len(rows) != 2 || rows[1].Team != "BUF" {
+		t.Fatalf("warm load: mapped %d rows (want 2, from the cold load only), got %+v", mapped, rows)
+	}
+	if es, _ := download.Entries(cfg.CacheBackend()); len(es) != 1 {
+		t.Fatalf("raw cache entries = %+v, want only the CSV", es)
+	}
+	if _, err := LoadFromSourceAs(ctx, src, 0, mapper); err != nil || mapped != 4 {
+		t.Fatalf("custom mapper: mapped = %d, err = %v; want a parse of its own", mapped, err)
+	}
+	etag = `"v2"` // upstream changed: the parsed entry no longer applies
+	load()
+	if mapped != 6 {
+		t.Fatalf("after ETag change mapped = %d, want 6", mapped)
+	}
+}
+
+// openCounter counts the bodies opened from a cache.
+type openCounter struct {
+	download.Cache
+	opens *atomic.Int32
+}
+
+func (c openCounter) Open(url string) (io.ReadCloser, download.Metadata, error) {
+	c.opens.Add(1)
+	return c.Cache.Open(url)
+}
+
+func TestLoadDatasetAs_ParsedHitSkipsRawBody(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ".csv") {
+			http.NotFound(w, r)
+			return
+		}
+		hits.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, "season,team\n2024,KC\n")
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	cfg := config.Resolve(
+		config.WithCacheMode(config.CacheModeFilesystem),
+		config.WithCacheDir(t.TempDir()),
+		config.WithCacheDuration(time.Hour),
+		config.WithPreferFormat(download.FormatCSV),
+	)
+	var opens atomic.Int32
+	cache := openCounter{Cache: cfg.CacheBackend(), opens: &opens}
+	dl := download.New(download.WithCache(cache), download.WithHTTPClient(&http.Client{Transport: rewriteTransport{u}}))
+	ctx := WithResolver(WithClient(WithConfig(context.Background(), cfg), dl), source.RawResolver{})
+	src := Source{Repo: "nflverse-data", Base: "injuries/injuries", Key: Injuries}
+
+	if _, err := LoadDatasetAs(ctx, src, 0, rowmap.Decode[injuryRow]); err != nil {
+		t.Fatal(err)
+	}
+	before := opens.Load()
+	rows, err := LoadDatasetAs(ctx, src, 0, rowmap.Decode[injuryRow])
+	if err != nil || len(rows) != 1 || rows[0].Team != "KC" {
+		t.Fatalf("warm load = %+v, %v", rows, err)
+	}
+	if opens.Load() != before || hits.Load() != 1 {
+		t.Fatalf("warm load opened %d cached bodies and made %d requests; want none", opens.Load()-before, hits.Load()-1)
+	}
+}
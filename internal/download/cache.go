@@ -1,6 +1,7 @@
 package download
 
 import (
+	"fmt"
 	"io"
 	"time"
 )
@@ -9,16 +10,127 @@ type Metadata struct {
 	ETag          string
 	LastModified  time.Time
 	ContentLength int64
+	// ContentEncoding is the response Content-Encoding (e.g., "gzip"), if any.
+	ContentEncoding string
+	// SavedAt is when a cached entry was stored or last revalidated.
+	SavedAt time.Time
+	// SHA256 is the hex SHA-256 of the body, recorded when it is cached;
+	// cached copies that no longer match are refetched. Fetch leaves it
+	// empty for a download, whose body hasn't been read yet.
+	SHA256 string
+	// Dataset and Season label the asset for the cache index (see WithAsset).
+	Dataset string
+	Season  int
+	// Attempts is the number of HTTP requests Fetch made before returning
+	// (1 without retries, 0 when a fresh cache entry was served); requests
+	// that resume a broken body later aren't counted.
+	Attempts int
+	// Shared reports that the body came from a concurrent caller's download
+	// of the same URL (read back from the cache once it was stored) rather
+	// than a request of this Fetch's own.
+	Shared bool
+	// Stale reports that an expired cache entry was served because
+	// revalidating it failed (see WithStaleIfError).
+	Stale bool
+	// Status says how Fetch produced the body (see CacheStatus).
+	Status CacheStatus
 }
 
-type Cache interface {
-	// Validators returns ETag/Last-Modified to use for conditional GET.
-	Validators(url string) (etag string, lastMod time.Time, ok bool)
+// CacheStatus says how Fetch served a body.
+type CacheStatus int
 
-	// StoreStream writes the response stream to cache and returns a ReadCloser
-	// that the caller reads from (so we only stream once).
-	StoreStream(url string, meta Metadata, body io.ReadCloser) (io.ReadCloser, io.Closer)
+const (
+	CacheDownloaded  CacheStatus = iota // a full (200) response
+	CacheFresh                          // a fresh cache entry; no request was made
+	CacheRevalidated                    // an expired entry the server confirmed (304)
+	CacheStale                          // an expired entry served without revalidation (offline or stale-if-error)
+)
 
-	// Open returns a cached object body and its metadata.
+func (s CacheStatus) String() string {
+	switch s {
+	case CacheDownloaded:
+		return "downloaded"
+	case CacheFresh:
+		return "fresh"
+	case CacheRevalidated:
+		return "revalidated"
+	case CacheStale:
+		return "stale"
+	default:
+		return fmt.Sprintf("CacheStatus(%d)", int(s))
+	}
+}
+
+// Cache stores downloaded bodies with the validators needed to revalidate
+// them. Implementations must be safe for concurrent use.
+//
+// Cache is the extension point for storage backends: besides the built-in
+// memory (NewMemCache), filesystem (NewFSCache), S3-compatible (NewS3Cache)
+// and fixture (NewFixtureCache) caches, any implementation can be passed
+// to WithCache, and NewLayeredCache stacks several into tiers. Backends may
+// also implement Index (listing/removal), Stater (counters), Partials
+// (resumable downloads) and Volatile (entries that die with the process).
+type Cache interface {
+	// Lookup returns the metadata of the entry for url and whether it is
+	// still fresh (within the cache's TTL). Expired entries are reported too
+	// (fresh == false) so their ETag/Last-Modified can be revalidated.
+	Lookup(url string) (meta Metadata, fresh, ok bool)
+
+	// Open returns a cached body and its metadata, regardless of age.
 	Open(url string) (io.ReadCloser, Metadata, error)
+
+	// Store saves body under url and returns a reader over the stored copy.
+	// A failed read or write returns an error and leaves any previous entry
+	// for url intact. meta.SavedAt is kept when set (an entry copied between
+	// caches keeps its age) and stamped with the current time otherwise;
+	// meta.SHA256, when empty, is computed from body. Fetch streams
+	// downloads into Store as they arrive and fails the read of body if the
+	// download doesn't check out.
+	Store(url string, meta Metadata, body io.Reader) (io.ReadCloser, error)
+
+	// Touch marks the entry for url as revalidated (a 304), making it fresh
+	// again. Non-empty validators in meta replace the stored ones.
+	Touch(url string, meta Metadata) error
+}
+
+// Volatile is implemented by caches whose entries only last as long as the
+// process, like the memory cache.
+type Volatile interface {
+	Volatile() bool
+}
+
+// Persistent reports whether c keeps its entries beyond this process: any
+// non-nil cache that doesn't declare itself Volatile.
+func Persistent(c Cache) bool {
+	if c == nil {
+		return false
+	}
+	v, ok := c.(Volatile)
+	return !ok || !v.Volatile()
+}
+
+// expired reports whether an entry saved at saved has outlived ttl
+// (ttl <= 0 never expires).
+func expired(saved time.Time, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(saved) > ttl
+}
+
+// stamp returns meta with SavedAt defaulted to now.
+func stamp(meta Metadata) Metadata {
+	if meta.SavedAt.IsZero() {
+		meta.SavedAt = time.Now().UTC()
+	}
+	return meta
+}
+
+// revalidated merges the validators of a 304 response into the stored meta.
+func revalidated(stored, resp Metadata) Metadata {
+	if resp.ETag != "" {
+		stored.ETag = resp.ETag
+	}
+	if !resp.LastModified.IsZero() {
+		stored.LastModified = resp.LastModified
+	}
+	stored.SavedAt = time.Now().UTC()
+	return stored
 }
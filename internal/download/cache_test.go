@@ -0,0 +1,209 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// etagServer serves body with an ETag and honors If-None-Match.
+func etagServer(t *testing.T, body string) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	var hits, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits, &notModified
+}
+
+func fetchString(t *testing.T, c *Client, url string) (string, Metadata) {
+	t.Helper()
+	rc, meta, err := c.Fetch(context.Background(), url)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), meta
+}
+
+// fetchErr fetches url and reads the body to the end, returning the error
+// from either step (downloads are checked as their body ends).
+func fetchErr(ctx context.Context, c *Client, url string) error {
+	rc, _, err := c.Fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(io.Discard, rc)
+	return err
+}
+
+func TestFSCache_FreshAndRevalidate(t *testing.T) {
+	srv, hits, notModified := etagServer(t, "a,b\n1,2\n")
+	dir := t.TempDir()
+	c := New(WithCache(NewFSCache(dir, time.Hour)))
+
+	if b, meta := fetchString(t, c, srv.URL); b != "a,b\n1,2\n" || meta.Attempts != 1 {
+		t.Fatalf("first fetch = %q, attempts %d", b, meta.Attempts)
+	}
+	if b, meta := fetchString(t, c, srv.URL); b != "a,b\n1,2\n" || meta.Attempts != 0 || meta.Status != CacheFresh || hits.Load() != 1 {
+		t.Fatalf("fresh fetch = %q, attempts %d, status %v, hits %d; want served from cache", b, meta.Attempts, meta.Status, hits.Load())
+	}
+
+	// Expire the entry: it must be revalidated, not downloaded again.
+	stale := New(WithCache(NewFSCache(dir, time.Nanosecond)))
+	if b, meta := fetchString(t, stale, srv.URL); b != "a,b\n1,2\n" || notModified.Load() != 1 || meta.Status != CacheRevalidated {
+		t.Fatalf("stale fetch = %q, 304s = %d, status %v; want a revalidated cache hit", b, notModified.Load(), meta.Status)
+	}
+	// The 304 refreshed the entry for the long-TTL view as well.
+	if _, fresh, ok := NewFSCache(dir, time.Hour).Lookup(srv.URL); !ok || !fresh {
+		t.Fatalf("after 304: fresh=%v ok=%v", fresh, ok)
+	}
+
+	tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp-*"))
+	if len(tmps) != 0 {
+		t.Fatalf("temp files left behind: %v", tmps)
+	}
+}
+
+func TestMemCache_Revalidate(t *testing.T) {
+	srv, hits, notModified := etagServer(t, "x")
+	c := New(WithCache(NewMemCache(time.Nanosecond)))
+	fetchString(t, c, srv.URL)
+	time.Sleep(time.Millisecond)
+	if b, _ := fetchString(t, c, srv.URL); b != "x" || hits.Load() != 2 || notModified.Load() != 1 {
+		t.Fatalf("got %q with %d hits / %d 304s", b, hits.Load(), notModified.Load())
+	}
+}
+
+func TestFSCache_WriteFailureSurfaces(t *testing.T) {
+	srv, _, _ := etagServer(t, "a,b\n1,2\n")
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := New(WithCache(NewFSCache(filepath.Join(blocker, "cache"), time.Hour)))
+	if err := fetchErr(context.Background(), c, srv.URL); err == nil {
+		t.Fatal("Fetch succeeded with an unwritable cache dir; want an error")
+	}
+}
+
+func TestFetch_Offline(t *testing.T) {
+	srv, hits, _ := etagServer(t, "a,b\n1,2\n")
+	cache := NewMemCache(time.Nanosecond)
+	fetchString(t, New(WithCache(cache)), srv.URL)
+	time.Sleep(time.Millisecond) // entry is now expired
+
+	off := New(WithCache(cache), WithOffline(true))
+	if b, _ := fetchString(t, off, srv.URL); b != "a,b\n1,2\n" || hits.Load() != 1 {
+		t.Fatalf("offline fetch = %q with %d hits; want the expired entry and no request", b, hits.Load())
+	}
+	_, _, err := off.Fetch(context.Background(), srv.URL+"/other.csv")
+	if !errors.Is(err, errs.ErrNotCached) || hits.Load() != 1 {
+		t.Fatalf("uncached offline fetch: err = %v, hits = %d; want ErrNotCached", err, hits.Load())
+	}
+	if _, _, err := New(WithOffline(true)).Fetch(context.Background(), srv.URL); !errors.Is(err, errs.ErrNotCached) {
+		t.Fatalf("offline without cache: err = %v, want ErrNotCached", err)
+	}
+}
+
+func TestFetch_StaleIfError(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "x")
+	}))
+	defer srv.Close()
+	cache := NewMemCache(time.Nanosecond)
+	fetchString(t, New(WithCache(cache)), srv.URL)
+	time.Sleep(time.Millisecond)
+	down.Store(true)
+
+	if _, _, err := New(WithCache(cache)).Fetch(context.Background(), srv.URL); !errors.Is(err, errs.ErrUpstreamUnavailable) {
+		t.Fatalf("without stale-if-error: err = %v, want ErrUpstreamUnavailable", err)
+	}
+	b, meta := fetchString(t, New(WithCache(cache), WithStaleIfError(true)), srv.URL)
+	if b != "x" || !meta.Stale {
+		t.Fatalf("stale-if-error: body %q, stale %v", b, meta.Stale)
+	}
+}
+
+func storeString(t *testing.T, c Cache, url, body string) {
+	t.Helper()
+	rc, err := c.Store(url, Metadata{}, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+}
+
+func open(t *testing.T, c Cache, url string) {
+	t.Helper()
+	rc, _, err := c.Open(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+}
+
+func TestCache_LRUEviction(t *testing.T) {
+	for name, c := range map[string]Cache{
+		"mem": NewMemCache(time.Hour, WithMaxBytes(10)),
+		"fs":  NewFSCache(t.TempDir(), time.Hour, WithMaxBytes(10)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			storeString(t, c, "a", "aaaa")
+			time.Sleep(10 * time.Millisecond) // distinct access times on coarse clocks
+			storeString(t, c, "b", "bbbb")
+			time.Sleep(10 * time.Millisecond)
+			open(t, c, "a") // b is now least recently used
+			time.Sleep(10 * time.Millisecond)
+			storeString(t, c, "c", "cccc")
+
+			if _, _, ok := c.Lookup("b"); ok {
+				t.Fatal("b survived; want it evicted as least recently used")
+			}
+			for _, u := range []string{"a", "c"} {
+				if _, _, ok := c.Lookup(u); !ok {
+					t.Fatalf("%s evicted", u)
+				}
+			}
+			st, _ := StatsOf(c)
+			if st.Evictions != 1 || st.Entries != 2 || st.Bytes != 8 || st.Hits != 2 || st.Misses != 1 {
+				t.Fatalf("stats = %+v", st)
+			}
+
+			storeString(t, c, "huge", "0123456789abc") // bigger than the whole cache
+			if _, _, ok := c.Lookup("huge"); ok {
+				t.Fatal("oversized entry kept")
+			}
+			if _, _, ok := c.Lookup("c"); !ok {
+				t.Fatal("oversized entry evicted the rest of the cache")
+			}
+		})
+	}
+}
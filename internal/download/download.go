@@ -7,7 +7,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
 )
 
 type Format int
@@ -15,12 +18,61 @@ type Format int
 const (
 	FormatParquet Format = iota
 	FormatCSV
+	FormatCSVGzip
 )
 
+// String returns the format name as accepted by ParseFormat.
+func (f Format) String() string {
+	switch f {
+	case FormatParquet:
+		return "parquet"
+	case FormatCSV:
+		return "csv"
+	case FormatCSVGzip:
+		return "csv.gz"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// Ext returns the file extension (including the leading dot) for f.
+func (f Format) Ext() string {
+	switch f {
+	case FormatCSV:
+		return ".csv"
+	case FormatCSVGzip:
+		return ".csv.gz"
+	default:
+		return ".parquet"
+	}
+}
+
+// FormatOfPath reports the Format implied by a path or URL's extension.
+func FormatOfPath(p string) (Format, bool) {
+	p = strings.ToLower(p)
+	switch {
+	case strings.HasSuffix(p, ".parquet"):
+		return FormatParquet, true
+	case strings.HasSuffix(p, ".csv.gz"):
+		return FormatCSVGzip, true
+	case strings.HasSuffix(p, ".csv"):
+		return FormatCSV, true
+	default:
+		return 0, false
+	}
+}
+
 type Client struct {
 	http      *http.Client
 	cache     Cache // interface in cache.go
 	userAgent string
+	retry     RetryPolicy   // zero value = single attempt
+	onAttempt func(Attempt) // optional, see WithRetryHook
+
+	offline      bool // serve only from cache, see WithOffline
+	staleIfError bool // see WithStaleIfError
+
+	flights flights // downloads in progress, see Fetch
 }
 
 type Option func(*Client)
@@ -29,6 +81,15 @@ func WithHTTPClient(h *http.Client) Option { return func(c *Client) { c.http = h
 func WithCache(cache Cache) Option         { return func(c *Client) { c.cache = cache } }
 func WithUserAgent(ua string) Option       { return func(c *Client) { c.userAgent = ua } }
 
+// WithOffline makes Fetch serve cached entries of any age and never touch
+// the network; uncached URLs fail with errs.ErrNotCached.
+func WithOffline(v bool) Option { return func(c *Client) { c.offline = v } }
+
+// WithStaleIfError makes Fetch fall back to an expired cache entry when
+// revalidating it fails with a transient error (5xx, rate limiting, or a
+// network failure). Metadata.Stale marks such responses.
+func WithStaleIfError(v bool) Option { return func(c *Client) { c.staleIfError = v } }
+
 func New(opts ...Option) *Client {
 	c := &Client{
 		http:      &http.Client{Timeout: 30 * time.Second},
@@ -40,63 +101,323 @@ func New(opts ...Option) *Client {
 	return c
 }
 
-// Fetch returns a readable body and closes it when the ctx is done.
+// Cache returns the client's cache, or nil when caching is off.
+func (c *Client) Cache() Cache { return c.cache }
+
+// Offline reports whether the client serves only from its cache.
+func (c *Client) Offline() bool { return c.offline }
+
+// StaleIfError reports whether the client serves expired entries when
+// revalidating them fails.
+func (c *Client) StaleIfError() bool { return c.staleIfError }
+
+// flightKey identifies a Fetch within one Client: callers only share a
+// download when they would also verify it against the same checksum.
+type flightKey struct {
+	url      string
+	checksum string
+}
+
+// flights tracks the downloads a Client has in progress, so concurrent
+// Fetches of a URL wait for one instead of repeating it.
+type flights struct {
+	mu    sync.Mutex
+	calls map[flightKey]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	err     error
+	waiters int
+}
+
+// join returns the call in progress for key, or starts one led by the
+// caller.
+func (f *flights) join(key flightKey) (call *flightCall, leader bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.calls[key]; ok {
+		c.waiters++
+		return c, false
+	}
+	if f.calls == nil {
+		f.calls = make(map[flightKey]*flightCall)
+	}
+	c := &flightCall{done: make(chan struct{})}
+	f.calls[key] = c
+	return c, true
+}
+
+// finish ends the leader's call with err and releases its waiters.
+func (f *flights) finish(key flightKey, c *flightCall, err error) {
+	f.mu.Lock()
+	delete(f.calls, key)
+	f.mu.Unlock()
+	c.err = err
+	close(c.done)
+}
+
+// waiters reports how many callers have joined the call for key.
+func (f *flights) waiters(key flightKey) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.calls[key]; ok {
+		return c.waiters
+	}
+	return 0
+}
+
+// Fetch returns the body of url.
 // If a cache is configured, it will attempt conditional GETs with ETag/Last-Modified.
+// Transient failures are retried per the client's RetryPolicy (see WithRetry);
+// Metadata.Attempts reports how many requests were made before the body
+// started.
+//
+// Downloads stream: the body is read from the network as the caller reads
+// it and written to the cache along the way. It is checked when it ends
+// (length, and the checksum set with WithChecksum) and only then cached;
+// a failed check or a broken transfer is returned by Read. Read the body to
+// EOF for it to be cached, and always Close it.
+//
+// Concurrent Fetches of the same URL through the same Client are coalesced
+// when there is a cache to share: one caller downloads it, and the others
+// wait until it is stored and then read the cache entry (Metadata.Shared
+// marks them).
 func (c *Client) Fetch(ctx context.Context, url string) (io.ReadCloser, Metadata, error) {
 	if c.http == nil {
 		return nil, Metadata{}, errors.New("nil http client")
 	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, Metadata{}, err
+	if c.cache == nil || c.offline {
+		return c.fetch(ctx, url) // nothing to share
 	}
-	if c.userAgent != "" {
-		req.Header.Set("User-Agent", c.userAgent)
+	key := flightKey{url, checksumFrom(ctx)}
+	shared := false
+	for {
+		call, leader := c.flights.join(key)
+		if !leader {
+			select {
+			case <-ctx.Done():
+				return nil, Metadata{}, ctx.Err()
+			case <-call.done:
+			}
+			if err := call.err; err != nil && !errors.Is(err, errAbandoned) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				return nil, Metadata{}, err
+			}
+			shared = call.err == nil
+			continue // read what it stored, or download it ourselves
+		}
+		rc, meta, err := c.fetch(ctx, url)
+		if b, ok := rc.(*body); ok {
+			b.onDone = func(err error) { c.flights.finish(key, call, err) }
+		} else {
+			c.flights.finish(key, call, err)
+		}
+		meta.Shared = shared && meta.Status != CacheDownloaded
+		return rc, meta, err
+	}
+}
+
+// fetch serves url from a fresh cache entry, or downloads it (revalidating
+// an expired entry with its validators) and streams it into the cache.
+// Cached copies are checked against their stored hash (see openVerified);
+// one found corrupt up front is dropped and downloaded again.
+func (c *Client) fetch(ctx context.Context, url string) (io.ReadCloser, Metadata, error) {
+	if c.offline {
+		return c.fetchOffline(url)
 	}
 
-	// If cache has validators, set If-None-Match / If-Modified-Since
-	var meta Metadata
+	var stale *Metadata
 	if c.cache != nil {
-		if tag, t, ok := c.cache.Validators(url); ok {
-			if tag != "" {
-				req.Header.Set("If-None-Match", tag)
+		if m, fresh, ok := c.cache.Lookup(url); ok && fresh {
+			if rc, meta, err := c.openVerified(url); err == nil {
+				meta.Status = CacheFresh
+				return rc, meta, nil
 			}
-			if !t.IsZero() {
-				req.Header.Set("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+			// The entry is gone, unreadable or corrupt; download it again.
+		} else if ok {
+			stale = &m
+		}
+	}
+	return c.download(ctx, url, stale)
+}
+
+// download GETs url, conditionally when stale (an expired cache entry) is
+// set, and streams a full response into the cache (see body).
+//
+// When the cache keeps partial downloads (see Partials), a body that breaks
+// off stays there and the next Fetch continues it with Range/If-Range; a
+// transfer that breaks off while the retry policy has attempts left is
+// continued right away (see body.resume). A server that ignores the range,
+// or whose ETag has changed, sends the whole file again.
+func (c *Client) download(ctx context.Context, url string, stale *Metadata) (io.ReadCloser, Metadata, error) {
+	var n int
+	for {
+		part := c.partial(url)
+		resp, tries, err := c.get(ctx, url, stale, part)
+		n += tries
+		if err == nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && part.validator != "" {
+			_ = resp.Body.Close()
+			c.dropPartial(url) // start over without the range
+			continue
+		}
+		if err == nil && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent && resp.StatusCode != http.StatusNotModified {
+			defer resp.Body.Close()
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
+			err = &HTTPError{URL: url, Code: resp.StatusCode, Body: string(b)}
+		}
+		if err != nil {
+			if stale != nil && c.staleIfError && transient(err) {
+				return c.serveStale(url, n, err)
 			}
+			return nil, Metadata{}, err
 		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			_ = resp.Body.Close()
+			if stale == nil {
+				return nil, Metadata{}, fmt.Errorf("%s: 304 without a cached entry", url)
+			}
+			c.dropPartial(url) // the cached copy is current
+			if err := c.cache.Touch(url, ParseRespMeta(resp)); err != nil {
+				return nil, Metadata{}, fmt.Errorf("caching %s: %w", url, err)
+			}
+			rc, meta, err := c.openVerified(url)
+			if errors.Is(err, errs.ErrIntegrity) {
+				// The server confirmed a copy we can no longer trust: fetch it
+				// in full instead.
+				rc, meta, err := c.download(ctx, url, nil)
+				meta.Attempts += n
+				return rc, meta, err
+			}
+			if err != nil {
+				return nil, Metadata{}, fmt.Errorf("caching %s: %w", url, err)
+			}
+			meta.Attempts, meta.Status = n, CacheRevalidated
+			return rc, meta, nil
+		}
+
+		var prefix io.ReadCloser
+		if resp.StatusCode == http.StatusPartialContent && part.size > 0 {
+			f, err := c.cache.(Partials).OpenPartial(url)
+			if err != nil {
+				_ = resp.Body.Close()
+				c.dropPartial(url) // unreadable; start over
+				continue
+			}
+			// Only the bytes Partial reported: another client may take the
+			// file over and append to it.
+			prefix = struct {
+				io.Reader
+				io.Closer
+			}{io.LimitReader(f, part.size), f}
+		}
+		meta := ParseRespMeta(resp) // pulls ETag/Last-Modified, Size, etc.
+		meta.Attempts = n
+		meta.Dataset, meta.Season = assetFrom(ctx)
+		b, err := c.stream(ctx, url, resp, meta, part, prefix)
+		if err != nil {
+			if stale != nil && c.staleIfError {
+				return c.serveStale(url, n, err)
+			}
+			return nil, Metadata{}, err
+		}
+		return b, b.meta, nil
 	}
+}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
+// serveStale falls back to the expired cache entry for url after n
+// requests failed with err; err is returned if the entry can't be read.
+func (c *Client) serveStale(url string, n int, err error) (io.ReadCloser, Metadata, error) {
+	rc, meta, oerr := c.openVerified(url)
+	if oerr != nil {
 		return nil, Metadata{}, err
 	}
+	meta.Attempts, meta.Stale, meta.Status = n, true, CacheStale
+	return rc, meta, nil
+}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		meta = ParseRespMeta(resp) // pulls ETag/Last-Modified, Size, etc.
-		if c.cache != nil {
-			// stream into cache while returning a tee'd reader
-			rc, _ := c.cache.StoreStream(url, meta, resp.Body)
-			return rc, meta, nil
+// fetchOffline serves url from the cache regardless of age.
+func (c *Client) fetchOffline(url string) (io.ReadCloser, Metadata, error) {
+	if c.cache != nil {
+		if _, fresh, ok := c.cache.Lookup(url); ok {
+			if rc, meta, err := c.openVerified(url); err == nil {
+				meta.Status = CacheFresh
+				if !fresh {
+					meta.Status = CacheStale
+				}
+				return rc, meta, nil
+			}
 		}
-		return resp.Body, meta, nil
+	}
+	return nil, Metadata{}, fmt.Errorf("%w (offline): %s", errs.ErrNotCached, url)
+}
 
-	case http.StatusNotModified:
-		if c.cache == nil {
+// transient reports whether err is worth hiding behind a stale cache entry.
+func transient(err error) bool {
+	return errors.Is(err, errs.ErrUpstreamUnavailable) || errors.Is(err, errs.ErrRateLimited)
+}
+
+// get GETs url, retrying transient failures per the client's RetryPolicy,
+// and returns the final response with the number of requests made.
+// Transport failures are reported as errs.ErrUpstreamUnavailable.
+func (c *Client) get(ctx context.Context, url string, validators *Metadata, part partial) (*http.Response, int, error) {
+	for n := 1; ; n++ {
+		resp, err := c.do(ctx, url, validators, part)
+		a := Attempt{URL: url, N: n, Err: err}
+		if resp != nil {
+			a.Status = resp.StatusCode
+		}
+		a.Retry = n < c.retry.MaxAttempts && retryable(ctx, resp, err)
+		if a.Retry {
+			a.Delay = c.retry.delay(n, resp)
+		}
+		if c.onAttempt != nil {
+			c.onAttempt(a)
+		}
+		if !a.Retry {
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, n, ctx.Err()
+				}
+				return nil, n, fmt.Errorf("%w: %w", errs.ErrUpstreamUnavailable, err)
+			}
+			return resp, n, nil
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // allow conn reuse
 			_ = resp.Body.Close()
-			return nil, Metadata{}, errors.New("304 but no cache configured")
 		}
-		_ = resp.Body.Close()
-		rc, meta, err := c.cache.Open(url)
-		return rc, meta, err
+		if err := sleepCtx(ctx, a.Delay); err != nil {
+			return nil, n, err
+		}
+	}
+}
 
-	default:
-		defer resp.Body.Close()
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 8<<10))
-		return nil, Metadata{}, &HTTPError{Code: resp.StatusCode, Body: string(b)}
+// do performs a single GET, adding the user agent and, for a cached entry
+// being revalidated, If-None-Match / If-Modified-Since. A partial download
+// is continued with Range, guarded by If-Range so a changed file comes back
+// whole.
+func (c *Client) do(ctx context.Context, url string, validators *Metadata, part partial) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if validators != nil {
+		if validators.ETag != "" {
+			req.Header.Set("If-None-Match", validators.ETag)
+		}
+		if !validators.LastModified.IsZero() {
+			req.Header.Set("If-Modified-Since", validators.LastModified.UTC().Format(http.TimeFormat))
+		}
+	}
+	if part.size > 0 && part.validator != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", part.size))
+		req.Header.Set("If-Range", part.validator)
 	}
+	return c.http.Do(req)
 }
 
 func ParseFormat(s string) (Format, error) {
@@ -106,6 +427,8 @@ func ParseFormat(s string) (Format, error) {
 		return FormatParquet, nil
 	case "csv":
 		return FormatCSV, nil
+	case "csv.gz", "csvgz", "gz":
+		return FormatCSVGzip, nil
 	default:
 		return 0, fmt.Errorf("unknown format %q", s)
 	}
@@ -0,0 +1,115 @@
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetch_CoalescesConcurrentCallers(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		io.WriteString(w, "season,team\n2024,KC\n")
+	}))
+	defer srv.Close()
+
+	c := New(WithCache(NewMemCache(time.Hour)))
+	const n = 4
+	bodies := make([]string, n)
+	shared := make([]bool, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rc, meta, err := c.Fetch(context.Background(), srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			bodies[i], shared[i] = string(b), meta.Shared
+		}()
+	}
+	for c.flights.waiters(flightKey{url: srv.URL}) < n-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Fatalf("server hits = %d, want 1", hits.Load())
+	}
+	nShared := 0
+	for i, b := range bodies {
+		if b != "season,team\n2024,KC\n" {
+			t.Fatalf("body[%d] = %q", i, b)
+		}
+		if shared[i] {
+			nShared++
+		}
+	}
+	if nShared != n-1 {
+		t.Fatalf("shared = %d, want %d", nShared, n-1)
+	}
+}
+
+func TestFetch_StreamsWhileCaching(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "season,team\n")
+		w.(http.Flusher).Flush()
+		<-release
+		io.WriteString(w, "2024,KC\n")
+	}))
+	defer srv.Close()
+	cache := NewFSCache(t.TempDir(), time.Hour)
+	c := New(WithCache(cache))
+
+	rc, _, err := c.Fetch(context.Background(), srv.URL+"/x.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	head := make([]byte, len("season,team\n"))
+	if _, err := io.ReadFull(rc, head); err != nil || string(head) != "season,team\n" {
+		t.Fatalf("first bytes = %q (%v) before the server finished", head, err)
+	}
+	if _, _, ok := cache.Lookup(srv.URL + "/x.csv"); ok {
+		t.Fatal("entry stored before the body ended")
+	}
+	close(release)
+	rest, err := io.ReadAll(rc)
+	if err != nil || string(rest) != "2024,KC\n" {
+		t.Fatalf("rest = %q (%v)", rest, err)
+	}
+	if m, fresh, ok := cache.Lookup(srv.URL + "/x.csv"); !ok || !fresh || m.SHA256 == "" {
+		t.Fatalf("after EOF: entry %+v, fresh %v, ok %v; want a fresh entry with its hash", m, fresh, ok)
+	}
+}
+
+func TestFetch_AbandonedBodyNotCached(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "season,team\n2024,KC\n")
+	}))
+	defer srv.Close()
+	cache := NewMemCache(time.Hour)
+	rc, _, err := New(WithCache(cache)).Fetch(context.Background(), srv.URL+"/x.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadFull(rc, make([]byte, 4))
+	rc.Close()
+	if _, _, ok := cache.Lookup(srv.URL + "/x.csv"); ok {
+		t.Fatal("a body closed halfway was cached")
+	}
+}
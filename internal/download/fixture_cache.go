@@ -0,0 +1,64 @@
+package download
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+type fixtureCache struct {
+	fsys fs.FS
+}
+
+// NewFixtureCache returns a read-only Cache over fsys for tests: the body
+// for a URL is the file at the URL without its scheme and query, e.g.
+// https://github.com/nflverse/nflverse-data/releases/download/pbp/play_by_play_2024.csv
+// is read from github.com/nflverse/nflverse-data/releases/download/pbp/play_by_play_2024.csv.
+// Fixtures never expire and Store keeps nothing, so results are the same
+// on every run; combine with WithOffline to fail on anything not in fsys.
+func NewFixtureCache(fsys fs.FS) Cache {
+	return &fixtureCache{fsys: fsys}
+}
+
+func fixturePath(url string) string {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		url = rest
+	}
+	url, _, _ = strings.Cut(url, "?")
+	return strings.TrimPrefix(url, "/")
+}
+
+func (c *fixtureCache) read(url string) ([]byte, Metadata, error) {
+	b, err := fs.ReadFile(c.fsys, fixturePath(url))
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	return b, Metadata{ContentLength: int64(len(b))}, nil
+}
+
+func (c *fixtureCache) Lookup(url string) (Metadata, bool, bool) {
+	_, meta, err := c.read(url)
+	if err != nil {
+		return Metadata{}, false, false
+	}
+	return meta, true, true
+}
+
+func (c *fixtureCache) Open(url string) (io.ReadCloser, Metadata, error) {
+	b, meta, err := c.read(url)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), meta, nil
+}
+
+func (c *fixtureCache) Store(url string, meta Metadata, body io.Reader) (io.ReadCloser, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (c *fixtureCache) Touch(url string, meta Metadata) error { return nil }
@@ -3,89 +3,386 @@ package download
 import (
 	"bytes"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
 type fsCache struct {
-	dir string
-	ttl time.Duration
+	dir      string
+	ttl      time.Duration
+	maxBytes int64 // 0 = unbounded
+	stats    counters
 }
 
 type sidecar struct {
+	URL          string    `json:"url,omitempty"`
+	Dataset      string    `json:"dataset,omitempty"`
+	Season       int       `json:"season,omitempty"`
 	ETag         string    `json:"etag,omitempty"`
 	LastModified time.Time `json:"last_modified,omitempty"`
 	Size         int64     `json:"size,omitempty"`
+	Encoding     string    `json:"encoding,omitempty"`
+	SHA256       string    `json:"sha256,omitempty"` // content hash, checked on read
 	SavedAt      time.Time `json:"saved_at"`
 }
 
+// sidecarOf is the sidecar recorded for a body of size bytes stored under url.
+func sidecarOf(url string, m Metadata, size int64) sidecar {
+	return sidecar{
+		URL:          url,
+		Dataset:      m.Dataset,
+		Season:       m.Season,
+		ETag:         m.ETag,
+		LastModified: m.LastModified,
+		Size:         size,
+		Encoding:     m.ContentEncoding,
+		SHA256:       m.SHA256,
+		SavedAt:      stamp(m).SavedAt,
+	}
+}
+
+func (sc sidecar) meta() Metadata {
+	return Metadata{
+		ETag:            sc.ETag,
+		LastModified:    sc.LastModified,
+		ContentLength:   sc.Size,
+		ContentEncoding: sc.Encoding,
+		SHA256:          sc.SHA256,
+		SavedAt:         sc.SavedAt,
+		Dataset:         sc.Dataset,
+		Season:          sc.Season,
+	}
+}
+
 // NewFSCache returns a filesystem-backed Cache. Files are stored as two
 // siblings: <hash>.data and <hash>.json containing ETag/Last-Modified/TTL info.
-func NewFSCache(dir string, ttl time.Duration) Cache {
+// Both are written to a temp file and renamed into place, so readers (in
+// this or another process) never see a partial file. The .json sidecars
+// double as the key index: they record the URL, dataset and season, so
+// entries can be listed and removed selectively (see Index).
+//
+// Interrupted downloads are kept as <hash>.part-* files (named by a
+// <hash>.partmeta sidecar) so they can be resumed; see Partials.
+//
+// With WithMaxBytes the directory is kept under a quota: after each store,
+// the least recently used entries (by the .data file's mtime, which Open
+// bumps) are deleted until the total fits.
+func NewFSCache(dir string, ttl time.Duration, opts ...CacheOption) Cache {
 	_ = os.MkdirAll(dir, 0o755)
-	return &fsCache{dir: dir, ttl: ttl}
+	return &fsCache{dir: dir, ttl: ttl, maxBytes: cacheOptions(opts).maxBytes}
 }
 
-func (c *fsCache) Validators(url string) (string, time.Time, bool) {
-	meta, ok := c.readMeta(url)
+func (c *fsCache) Lookup(url string) (Metadata, bool, bool) {
+	sc, ok := c.readMeta(url)
 	if !ok {
-		return "", time.Time{}, false
+		c.stats.miss()
+		return Metadata{}, false, false
+	}
+	fresh := !expired(sc.SavedAt, c.ttl)
+	c.stats.lookup(fresh)
+	return sc.meta(), fresh, true
+}
+
+func (c *fsCache) Store(url string, m Metadata, body io.Reader) (io.ReadCloser, error) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, err
+	}
+	base := c.base(url)
+	h := sha256.New()
+	n, err := writeAtomic(base+".data", io.TeeReader(body, h))
+	if err != nil {
+		return nil, err
+	}
+	if m.SHA256 == "" {
+		m.SHA256 = hex.EncodeToString(h.Sum(nil))
 	}
-	// TTL: if expired, fetch fresh (skip validators to force 200)
-	if c.ttl > 0 && time.Since(meta.SavedAt) > c.ttl {
-		return "", time.Time{}, false
+	if err := c.writeMeta(url, sidecarOf(url, m, n)); err != nil {
+		return nil, err
 	}
-	return meta.ETag, meta.LastModified, true
+	f, err := os.Open(base + ".data")
+	if err != nil {
+		return nil, err
+	}
+	if c.maxBytes > 0 {
+		if err := c.enforceQuota(url); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return f, nil
 }
 
-func (c *fsCache) StoreStream(url string, m Metadata, body io.ReadCloser) (io.ReadCloser, io.Closer) {
-	defer body.Close()
-	b, err := io.ReadAll(body)
+func (c *fsCache) Open(url string) (io.ReadCloser, Metadata, error) {
+	path := c.base(url) + ".data"
+	f, err := os.Open(path)
 	if err != nil {
-		return io.NopCloser(bytes.NewReader(nil)), io.NopCloser(nil)
+		return nil, Metadata{}, err
 	}
-	_ = os.MkdirAll(c.dir, 0o755)
+	now := time.Now()
+	_ = os.Chtimes(path, now, now) // record the access for LRU eviction
+	sc, _ := c.readMeta(url)
+	return f, sc.meta(), nil
+}
 
-	base := c.base(url)
-	_ = os.WriteFile(base+".data", b, 0o644)
+// enforceQuota evicts least recently used entries until the cache fits in
+// maxBytes. The entry just stored (keep) is never evicted to make room; if
+// it alone is over the limit, it is dropped instead (the caller's open file
+// stays readable) and the rest of the cache is left alone.
+func (c *fsCache) enforceQuota(keep string) error {
+	es, err := c.Entries()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, e := range es {
+		if e.URL == keep && e.Size > c.maxBytes {
+			return c.Remove(keep)
+		}
+		total += e.Size
+	}
+	slices.SortFunc(es, func(a, b Entry) int { return a.AccessedAt.Compare(b.AccessedAt) })
+	for _, e := range es {
+		if total <= c.maxBytes {
+			break
+		}
+		if e.URL == keep {
+			continue
+		}
+		if err := c.Remove(e.URL); err != nil {
+			return err
+		}
+		total -= e.Size
+		c.stats.evictions.Add(1)
+	}
+	return nil
+}
 
-	sc := sidecar{
-		ETag:         m.ETag,
-		LastModified: m.LastModified,
-		Size:         int64(len(b)),
-		SavedAt:      time.Now().UTC(),
+func (c *fsCache) Stats() CacheStats {
+	es, _ := c.Entries()
+	var total int64
+	for _, e := range es {
+		total += e.Size
 	}
-	if j, err := json.Marshal(sc); err == nil {
-		_ = os.WriteFile(base+".json", j, 0o644)
+	return c.stats.snapshot(len(es), total)
+}
+
+func (c *fsCache) Touch(url string, m Metadata) error {
+	sc, ok := c.readMeta(url)
+	if !ok {
+		return os.ErrNotExist
 	}
-	return io.NopCloser(bytes.NewReader(b)), io.NopCloser(nil)
+	rm := revalidated(sc.meta(), m)
+	sc.ETag, sc.LastModified, sc.SavedAt = rm.ETag, rm.LastModified, rm.SavedAt
+	return c.writeMeta(url, sc)
 }
 
-func (c *fsCache) Open(url string) (io.ReadCloser, Metadata, error) {
-	base := c.base(url)
-	b, err := os.ReadFile(base + ".data")
+// Entries lists the cache from its sidecars. Sidecars written before the
+// index existed (no URL) are skipped.
+func (c *fsCache) Entries() ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
 	if err != nil {
-		return nil, Metadata{}, err
+		return nil, err
 	}
-	meta := Metadata{}
-	if j, err := os.ReadFile(base + ".json"); err == nil {
+	var out []Entry
+	for _, p := range paths {
+		j, err := os.ReadFile(p)
+		if err != nil {
+			continue // removed concurrently
+		}
 		var sc sidecar
-		if json.Unmarshal(j, &sc) == nil {
-			meta.ETag = sc.ETag
-			meta.LastModified = sc.LastModified
-			meta.ContentLength = sc.Size
+		if json.Unmarshal(j, &sc) != nil || sc.URL == "" {
+			continue
+		}
+		e := entryOf(sc.URL, sc.meta(), sc.Size, c.ttl)
+		if fi, err := os.Stat(strings.TrimSuffix(p, ".json") + ".data"); err == nil {
+			e.AccessedAt = fi.ModTime().UTC()
+		}
+		out = append(out, e)
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+// Remove deletes url's sidecar and then its data, so a concurrent Lookup
+// never finds a sidecar without data, along with any partial download.
+func (c *fsCache) Remove(url string) error {
+	base := c.base(url)
+	for _, p := range []string{base + ".json", base + ".data"} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return c.DropPartial(url)
+}
+
+// partMeta is the sidecar of a partial download (<hash>.partmeta). It names
+// the file holding the received bytes, so publishing a partial download is
+// one atomic write of the sidecar.
+type partMeta struct {
+	URL       string    `json:"url"`
+	Validator string    `json:"validator"`
+	File      string    `json:"file"` // in the cache dir
+	SavedAt   time.Time `json:"saved_at"`
+}
+
+func (c *fsCache) readPartMeta(url string) (partMeta, bool) {
+	j, err := os.ReadFile(c.base(url) + ".partmeta")
+	if err != nil {
+		return partMeta{}, false
+	}
+	var pm partMeta
+	if json.Unmarshal(j, &pm) != nil || pm.URL != url || pm.File == "" || filepath.Base(pm.File) != pm.File {
+		return partMeta{}, false
+	}
+	return pm, true
+}
+
+func (c *fsCache) Partial(url string) (int64, string, bool) {
+	pm, ok := c.readPartMeta(url)
+	if !ok {
+		return 0, "", false
+	}
+	fi, err := os.Stat(filepath.Join(c.dir, pm.File))
+	if err != nil {
+		return 0, "", false
+	}
+	return fi.Size(), pm.Validator, true
+}
+
+func (c *fsCache) OpenPartial(url string) (io.ReadCloser, error) {
+	pm, ok := c.readPartMeta(url)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return os.Open(filepath.Join(c.dir, pm.File))
+}
+
+// WritePartial gives every writer a file of its own (<hash>.part-*), so
+// concurrent downloads of url in this or other processes never write to
+// the same file. Continuing the kept partial download claims its file by
+// renaming it; only one writer can win that rename.
+func (c *fsCache) WritePartial(url, validator string, offset int64) (PartialWriter, error) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(c.dir, hashKey(url)+".part-*")
+	if err != nil {
+		return nil, err
+	}
+	w := &fsPartial{c: c, url: url, validator: validator, f: f}
+	if offset == 0 {
+		return w, nil
+	}
+	f.Close()
+	pm, ok := c.readPartMeta(url)
+	if ok && pm.Validator == validator {
+		err = os.Rename(filepath.Join(c.dir, pm.File), f.Name())
+	}
+	if fi, serr := os.Stat(f.Name()); !ok || pm.Validator != validator || err != nil || serr != nil || fi.Size() != offset {
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("%s: no partial download of %s at byte %d", url, validator, offset)
+	}
+	if w.f, err = os.OpenFile(f.Name(), os.O_WRONLY|os.O_APPEND, 0); err != nil {
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return w, nil
+}
+
+// fsPartial is one writer's download into its own file in the cache dir.
+type fsPartial struct {
+	c              *fsCache
+	url, validator string
+	f              *os.File
+}
+
+func (w *fsPartial) Write(p []byte) (int, error) { return w.f.Write(p) }
+
+// Commit renames the file over the entry's data and then writes its
+// sidecar, like Store.
+func (w *fsPartial) Commit(m Metadata) error {
+	if err := w.f.Close(); err != nil {
+		_ = os.Remove(w.f.Name())
+		return err
+	}
+	fi, err := os.Stat(w.f.Name())
+	if err == nil {
+		err = os.Rename(w.f.Name(), w.c.base(w.url)+".data")
+	}
+	if err != nil {
+		_ = os.Remove(w.f.Name())
+		return err
+	}
+	if err := w.c.writeMeta(w.url, sidecarOf(w.url, m, fi.Size())); err != nil {
+		return err
+	}
+	if w.c.maxBytes > 0 {
+		return w.c.enforceQuota(w.url)
+	}
+	return nil
+}
+
+// Abort publishes the file as url's partial download (replacing any other)
+// when keep is set, and deletes it otherwise.
+func (w *fsPartial) Abort(keep bool) error {
+	_ = w.f.Close()
+	if !keep || fileSize(w.f.Name()) == 0 {
+		return os.Remove(w.f.Name())
+	}
+	old, hadOld := w.c.readPartMeta(w.url)
+	j, err := json.Marshal(partMeta{URL: w.url, Validator: w.validator, File: filepath.Base(w.f.Name()), SavedAt: time.Now().UTC()})
+	if err == nil {
+		_, err = writeAtomic(w.c.base(w.url)+".partmeta", bytes.NewReader(j))
+	}
+	if err != nil {
+		_ = os.Remove(w.f.Name())
+		return err
+	}
+	if hadOld {
+		_ = os.Remove(filepath.Join(w.c.dir, old.File)) // superseded
+	}
+	return nil
+}
+
+func (c *fsCache) DropPartial(url string) error {
+	pm, ok := c.readPartMeta(url)
+	if err := os.Remove(c.base(url) + ".partmeta"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if ok {
+		if err := os.Remove(filepath.Join(c.dir, pm.File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
 		}
 	}
-	return io.NopCloser(bytes.NewReader(b)), meta, nil
+	return nil
+}
+
+// fileSize returns the size of the file at path, or 0 if it can't be read.
+func fileSize(path string) int64 {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
 }
 
 func (c *fsCache) base(url string) string {
+	return filepath.Join(c.dir, hashKey(url))
+}
+
+// hashKey is the file/object name stem for url.
+func hashKey(url string) string {
 	h := sha1.Sum([]byte(url))
-	return filepath.Join(c.dir, hex.EncodeToString(h[:]))
+	return hex.EncodeToString(h[:])
 }
 
 func (c *fsCache) readMeta(url string) (sidecar, bool) {
@@ -97,9 +394,36 @@ func (c *fsCache) readMeta(url string) (sidecar, bool) {
 	if err := json.Unmarshal(j, &sc); err != nil {
 		return sidecar{}, false
 	}
-	// TTL gate (also enforced in Validators)
-	if c.ttl > 0 && time.Since(sc.SavedAt) > c.ttl {
-		return sidecar{}, false
-	}
 	return sc, true
 }
+
+func (c *fsCache) writeMeta(url string, sc sidecar) error {
+	j, err := json.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	_, err = writeAtomic(c.base(url)+".json", bytes.NewReader(j))
+	return err
+}
+
+// writeAtomic copies r to a temp file next to path and renames it over
+// path, returning the bytes written. On error the temp file is removed and
+// path is left untouched.
+func writeAtomic(path string, r io.Reader) (int64, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return 0, err
+	}
+	return n, nil
+}
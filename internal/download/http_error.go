@@ -1,9 +1,18 @@
 package download
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"strings"
 
-// HTTPError represents a non-2xx HTTP status with a small body preview.
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// HTTPError represents a non-2xx HTTP status with a small body preview. It
+// matches errs.ErrNotFound (404/410), errs.ErrRateLimited (429, or GitHub's
+// rate-limit 403) and errs.ErrUpstreamUnavailable (5xx) via errors.Is.
 type HTTPError struct {
+	URL  string
 	Code int
 	Body string
 }
@@ -12,8 +21,25 @@ func (e *HTTPError) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
+	msg := fmt.Sprintf("http error: %d", e.Code)
+	if e.URL != "" {
+		msg += " " + e.URL
+	}
 	if e.Body == "" {
-		return fmt.Sprintf("http error: %d", e.Code)
+		return msg
+	}
+	return msg + ": " + e.Body
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case errs.ErrNotFound:
+		return e.Code == http.StatusNotFound || e.Code == http.StatusGone
+	case errs.ErrRateLimited:
+		return e.Code == http.StatusTooManyRequests ||
+			(e.Code == http.StatusForbidden && strings.Contains(strings.ToLower(e.Body), "rate limit"))
+	case errs.ErrUpstreamUnavailable:
+		return e.Code >= 500
 	}
-	return fmt.Sprintf("http error: %d: %s", e.Code, e.Body)
+	return false
 }
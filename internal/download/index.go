@@ -0,0 +1,161 @@
+package download
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry describes one cached asset, as recorded in a cache's key index.
+type Entry struct {
+	URL     string
+	Dataset string // dataset key the asset was fetched for; "" when unlabeled
+	Season  int    // 0 for all-season assets
+	Size    int64
+	ETag    string
+	SavedAt time.Time
+	// AccessedAt is when the entry was last served (eviction order).
+	AccessedAt time.Time
+	Expired    bool // older than the cache's TTL
+}
+
+// Name is the entry's "<dataset>/<season>" form matched by Clear; the
+// season is "all" for all-season assets.
+func (e Entry) Name() string {
+	season := "all"
+	if e.Season > 0 {
+		season = strconv.Itoa(e.Season)
+	}
+	return e.Dataset + "/" + season
+}
+
+// Index is implemented by caches that keep a key index, so their entries can
+// be listed and removed one by one. Both built-in caches implement it.
+type Index interface {
+	Entries() ([]Entry, error)
+	Remove(url string) error
+}
+
+// ErrNoIndex is returned by the management helpers for caches that don't
+// implement Index.
+var ErrNoIndex = errors.New("cache has no key index")
+
+func indexOf(c Cache) (Index, error) {
+	if c == nil {
+		return nil, nil
+	}
+	ix, ok := c.(Index)
+	if !ok {
+		return nil, ErrNoIndex
+	}
+	return ix, nil
+}
+
+// Entries lists c's entries (none for a nil cache).
+func Entries(c Cache) ([]Entry, error) {
+	ix, err := indexOf(c)
+	if ix == nil {
+		return nil, err
+	}
+	return ix.Entries()
+}
+
+// Clear removes the entries whose Name matches pattern (path.Match syntax)
+// and returns how many were removed. A pattern without a "/" matches every
+// season of the datasets it names:
+//
+//	Clear(c, "pbp")          // all play-by-play entries
+//	Clear(c, "*/2023")       // every dataset's 2023 assets
+//	Clear(c, "rosters*/202?")
+//	Clear(c, "*")            // everything
+func Clear(c Cache, pattern string) (int, error) {
+	if !strings.Contains(pattern, "/") {
+		pattern += "/*"
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, fmt.Errorf("clear %q: %w", pattern, err)
+	}
+	return removeWhere(c, func(e Entry) bool {
+		ok, _ := path.Match(pattern, e.Name())
+		return ok
+	})
+}
+
+// Prune removes expired entries and returns how many were removed.
+func Prune(c Cache) (int, error) {
+	return removeWhere(c, func(e Entry) bool { return e.Expired })
+}
+
+// TotalSize sums the size of every entry in c.
+func TotalSize(c Cache) (int64, error) {
+	es, err := Entries(c)
+	var n int64
+	for _, e := range es {
+		n += e.Size
+	}
+	return n, err
+}
+
+func removeWhere(c Cache, match func(Entry) bool) (int, error) {
+	ix, err := indexOf(c)
+	if ix == nil {
+		return 0, err
+	}
+	es, err := ix.Entries()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range es {
+		if !match(e) {
+			continue
+		}
+		if err := ix.Remove(e.URL); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func entryOf(url string, m Metadata, size int64, ttl time.Duration) Entry {
+	return Entry{
+		URL:     url,
+		Dataset: m.Dataset,
+		Season:  m.Season,
+		Size:    size,
+		ETag:    m.ETag,
+		SavedAt: m.SavedAt,
+		Expired: expired(m.SavedAt, ttl),
+	}
+}
+
+func sortEntries(es []Entry) {
+	slices.SortFunc(es, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(a.Dataset, b.Dataset), cmp.Compare(a.Season, b.Season), cmp.Compare(a.URL, b.URL))
+	})
+}
+
+type assetKey struct{}
+
+type assetLabel struct {
+	dataset string
+	season  int
+}
+
+// WithAsset labels the fetches made with ctx as belonging to dataset and
+// season (0 = all seasons), which caches record in their key index.
+func WithAsset(ctx context.Context, dataset string, season int) context.Context {
+	return context.WithValue(ctx, assetKey{}, assetLabel{dataset, season})
+}
+
+func assetFrom(ctx context.Context) (dataset string, season int) {
+	l, _ := ctx.Value(assetKey{}).(assetLabel)
+	return l.dataset, l.season
+}
@@ -0,0 +1,75 @@
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFSCacheIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		io.WriteString(w, r.URL.Path) // size == len(path)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := New(WithCache(NewFSCache(dir, time.Hour)))
+	for _, a := range []struct {
+		dataset string
+		season  int
+		path    string
+	}{
+		{"pbp", 2023, "/pbp_2023.csv"},
+		{"pbp", 2024, "/pbp_2024.csv"},
+		{"rosters", 2023, "/roster_2023.csv"},
+		{"players", 0, "/players.csv"},
+	} {
+		if err := fetchErr(WithAsset(context.Background(), a.dataset, a.season), c, srv.URL+a.path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A fresh view of the directory sees the persisted index.
+	cache := NewFSCache(dir, time.Hour)
+	es, err := Entries(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range es {
+		names = append(names, e.Name())
+	}
+	if got := names; len(got) != 4 || got[0] != "pbp/2023" || got[1] != "pbp/2024" || got[2] != "players/all" || got[3] != "rosters/2023" {
+		t.Fatalf("entries = %v", got)
+	}
+	if es[0].URL != srv.URL+"/pbp_2023.csv" || es[0].ETag != `"/pbp_2023.csv"` || es[0].Size != 13 || es[0].SavedAt.IsZero() {
+		t.Fatalf("entry = %+v", es[0])
+	}
+	if n, _ := TotalSize(cache); n != 13+13+16+12 {
+		t.Fatalf("TotalSize = %d", n)
+	}
+
+	if n, err := Clear(cache, "*/2023"); err != nil || n != 2 {
+		t.Fatalf("Clear(*/2023) = %d, %v", n, err)
+	}
+	if n, err := Clear(cache, "pbp"); err != nil || n != 1 {
+		t.Fatalf("Clear(pbp) = %d, %v", n, err)
+	}
+	if _, _, ok := cache.Lookup(srv.URL + "/pbp_2024.csv"); ok {
+		t.Fatal("cleared entry still found")
+	}
+
+	if n, _ := Prune(cache); n != 0 {
+		t.Fatalf("Prune(fresh) = %d, want 0", n)
+	}
+	if n, _ := Prune(NewFSCache(dir, time.Nanosecond)); n != 1 {
+		t.Fatalf("Prune(expired) = %d, want 1", n)
+	}
+	if es, _ := Entries(cache); len(es) != 0 {
+		t.Fatalf("entries after prune = %v", es)
+	}
+}
@@ -0,0 +1,278 @@
+package download
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type layeredCache struct {
+	layers []Cache
+}
+
+// NewLayeredCache stacks caches into tiers, fastest first (for example
+// memory → disk → S3):
+//
+//   - Lookup and Open consult the tiers in order. A fresh entry in any tier
+//     wins over a stale one in an earlier tier.
+//   - An entry opened from a lower tier is copied into the tiers above it
+//     (keeping its SavedAt), so the next read is served from the top.
+//   - Store writes through to every tier and succeeds when at least one
+//     tier stored the body; Touch and Remove apply to every tier.
+//
+// Nil layers are skipped.
+func NewLayeredCache(layers ...Cache) Cache {
+	c := &layeredCache{}
+	for _, l := range layers {
+		if l != nil {
+			c.layers = append(c.layers, l)
+		}
+	}
+	return c
+}
+
+func (c *layeredCache) Lookup(url string) (Metadata, bool, bool) {
+	var (
+		stale Metadata
+		found bool
+	)
+	for _, l := range c.layers {
+		m, fresh, ok := l.Lookup(url)
+		if ok && fresh {
+			return m, true, true
+		}
+		if ok && !found {
+			stale, found = m, true
+		}
+	}
+	return stale, false, found
+}
+
+func (c *layeredCache) Open(url string) (io.ReadCloser, Metadata, error) {
+	var errs []error
+	for _, i := range c.openOrder(url) {
+		rc, meta, err := c.layers[i].Open(url)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i == 0 {
+			return rc, meta, nil
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, upper := range c.layers[:i] {
+			if urc, err := upper.Store(url, meta, bytes.NewReader(b)); err == nil {
+				urc.Close()
+			}
+		}
+		return io.NopCloser(bytes.NewReader(b)), meta, nil
+	}
+	return nil, Metadata{}, fmt.Errorf("%s: not cached in any layer: %w", url, errors.Join(errs...))
+}
+
+// openOrder lists the tier indexes to open url from: the tier Lookup
+// reports (the first fresh one, else the first stale one), then the rest
+// top-down as fallbacks.
+func (c *layeredCache) openOrder(url string) []int {
+	best := -1
+	for i, l := range c.layers {
+		_, fresh, ok := l.Lookup(url)
+		if ok && fresh {
+			best = i
+			break
+		}
+		if ok && best < 0 {
+			best = i
+		}
+	}
+	order := make([]int, 0, len(c.layers))
+	if best >= 0 {
+		order = append(order, best)
+	}
+	for i := range c.layers {
+		if i != best {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+func (c *layeredCache) Store(url string, meta Metadata, body io.Reader) (io.ReadCloser, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	meta = stamp(meta) // every tier records the same age
+	if meta.SHA256 == "" {
+		meta.SHA256 = hashOf(b)
+	}
+	var errs []error
+	for _, l := range c.layers {
+		rc, err := l.Store(url, meta, bytes.NewReader(b))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rc.Close()
+	}
+	if len(errs) == len(c.layers) && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (c *layeredCache) Touch(url string, meta Metadata) error {
+	var errs []error
+	for _, l := range c.layers {
+		if _, _, ok := l.Lookup(url); !ok {
+			continue
+		}
+		if err := l.Touch(url, meta); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Entries merges the tiers that implement Index; for a URL cached in
+// several tiers, the highest tier's entry is reported.
+func (c *layeredCache) Entries() ([]Entry, error) {
+	seen := map[string]bool{}
+	var out []Entry
+	for _, l := range c.layers {
+		es, err := Entries(l)
+		if errors.Is(err, ErrNoIndex) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			if !seen[e.URL] {
+				seen[e.URL] = true
+				out = append(out, e)
+			}
+		}
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+func (c *layeredCache) Remove(url string) error {
+	var errs []error
+	for _, l := range c.layers {
+		if ix, ok := l.(Index); ok {
+			errs = append(errs, ix.Remove(url))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Volatile reports whether no tier persists.
+func (c *layeredCache) Volatile() bool {
+	for _, l := range c.layers {
+		if Persistent(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// Stats sums the counters of the tiers that keep them (an entry held by two
+// tiers counts twice).
+func (c *layeredCache) Stats() CacheStats {
+	var st CacheStats
+	for _, l := range c.layers {
+		if s, ok := StatsOf(l); ok {
+			st.Hits += s.Hits
+			st.Misses += s.Misses
+			st.Evictions += s.Evictions
+			st.Entries += s.Entries
+			st.Bytes += s.Bytes
+		}
+	}
+	return st
+}
+
+// Partial downloads live in the first tier that can keep them (see
+// Partials), returned with its index; without one, downloads aren't
+// resumable.
+func (c *layeredCache) partials() (Partials, int) {
+	for i, l := range c.layers {
+		if p, ok := l.(Partials); ok {
+			return p, i
+		}
+	}
+	return nil, -1
+}
+
+var errNoPartials = errors.New("no tier keeps partial downloads")
+
+func (c *layeredCache) Partial(url string) (int64, string, bool) {
+	if p, _ := c.partials(); p != nil {
+		return p.Partial(url)
+	}
+	return 0, "", false
+}
+
+func (c *layeredCache) OpenPartial(url string) (io.ReadCloser, error) {
+	if p, _ := c.partials(); p != nil {
+		return p.OpenPartial(url)
+	}
+	return nil, errNoPartials
+}
+
+func (c *layeredCache) WritePartial(url, validator string, offset int64) (PartialWriter, error) {
+	p, i := c.partials()
+	if p == nil {
+		return nil, errNoPartials
+	}
+	w, err := p.WritePartial(url, validator, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &layeredPartial{PartialWriter: w, c: c, tier: i, url: url}, nil
+}
+
+// layeredPartial commits a download in the tier that kept it, then copies
+// the entry into the other tiers, as Store writes through to all of them.
+type layeredPartial struct {
+	PartialWriter
+	c    *layeredCache
+	tier int
+	url  string
+}
+
+func (w *layeredPartial) Commit(meta Metadata) error {
+	meta = stamp(meta) // every tier records the same age
+	if err := w.PartialWriter.Commit(meta); err != nil {
+		return err
+	}
+	for j, l := range w.c.layers {
+		if j == w.tier {
+			continue
+		}
+		rc, m, err := w.c.layers[w.tier].Open(w.url)
+		if err != nil {
+			return nil // evicted already (quota); the other tiers just miss
+		}
+		if lrc, err := l.Store(w.url, m, rc); err == nil {
+			lrc.Close()
+		}
+		rc.Close()
+	}
+	return nil
+}
+
+func (c *layeredCache) DropPartial(url string) error {
+	if p, _ := c.partials(); p != nil {
+		return p.DropPartial(url)
+	}
+	return nil
+}
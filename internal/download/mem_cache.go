@@ -2,71 +2,138 @@ package download
 
 import (
 	"bytes"
+	"container/list"
+	"fmt"
 	"io"
 	"sync"
 	"time"
 )
 
+type memEntry struct {
+	url        string
+	b          []byte
+	meta       Metadata
+	accessedAt time.Time
+}
+
 type memCache struct {
-	ttl  time.Duration
-	mu   sync.RWMutex
-	data map[string]struct {
-		b    []byte
-		meta Metadata
-		ts   time.Time
-	}
+	ttl      time.Duration
+	maxBytes int64 // 0 = unbounded
+
+	mu    sync.Mutex
+	data  map[string]*list.Element // of *memEntry
+	lru   *list.List               // front = most recently used
+	bytes int64
+	stats counters
 }
 
-// NewMemCache returns an in-memory Cache with a simple TTL.
-func NewMemCache(ttl time.Duration) Cache {
-	return &memCache{
-		ttl: ttl,
-		data: make(map[string]struct {
-			b    []byte
-			meta Metadata
-			ts   time.Time
-		}),
-	}
+// NewMemCache returns an in-memory Cache with a simple TTL. With
+// WithMaxBytes, the least recently used entries are evicted to keep the
+// cached bodies within the limit.
+func NewMemCache(ttl time.Duration, opts ...CacheOption) Cache {
+	o := cacheOptions(opts)
+	return &memCache{ttl: ttl, maxBytes: o.maxBytes, data: make(map[string]*list.Element), lru: list.New()}
 }
 
-func (c *memCache) Validators(url string) (string, time.Time, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	e, ok := c.data[url]
+func (c *memCache) Lookup(url string) (Metadata, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.data[url]
 	if !ok {
-		return "", time.Time{}, false
-	}
-	if c.ttl > 0 && time.Since(e.ts) > c.ttl {
-		return "", time.Time{}, false
+		c.stats.miss()
+		return Metadata{}, false, false
 	}
-	return e.meta.ETag, e.meta.LastModified, true
+	e := el.Value.(*memEntry)
+	fresh := !expired(e.meta.SavedAt, c.ttl)
+	c.stats.lookup(fresh)
+	return e.meta, fresh, true
 }
 
-func (c *memCache) StoreStream(url string, meta Metadata, body io.ReadCloser) (io.ReadCloser, io.Closer) {
-	defer body.Close()
+func (c *memCache) Store(url string, meta Metadata, body io.Reader) (io.ReadCloser, error) {
 	b, err := io.ReadAll(body)
 	if err != nil {
-		return io.NopCloser(bytes.NewReader(nil)), io.NopCloser(nil)
+		return nil, err
+	}
+	meta = stamp(meta)
+	if meta.SHA256 == "" {
+		meta.SHA256 = hashOf(b)
 	}
 	c.mu.Lock()
-	c.data[url] = struct {
-		b    []byte
-		meta Metadata
-		ts   time.Time
-	}{append([]byte(nil), b...), meta, time.Now()}
-	c.mu.Unlock()
-	return io.NopCloser(bytes.NewReader(b)), io.NopCloser(nil)
+	defer c.mu.Unlock()
+	c.remove(url)
+	if c.maxBytes > 0 && int64(len(b)) > c.maxBytes {
+		return io.NopCloser(bytes.NewReader(b)), nil // too big to keep at all
+	}
+	c.data[url] = c.lru.PushFront(&memEntry{url: url, b: b, meta: meta, accessedAt: meta.SavedAt})
+	c.bytes += int64(len(b))
+	for c.maxBytes > 0 && c.bytes > c.maxBytes {
+		c.remove(c.lru.Back().Value.(*memEntry).url)
+		c.stats.evictions.Add(1)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
 }
 
 func (c *memCache) Open(url string) (io.ReadCloser, Metadata, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	e, ok := c.data[url]
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.data[url]
 	if !ok {
-		return nil, Metadata{}, io.EOF
-	}
-	if c.ttl > 0 && time.Since(e.ts) > c.ttl {
-		return nil, Metadata{}, io.EOF
+		return nil, Metadata{}, fmt.Errorf("%s: not cached", url)
 	}
+	c.lru.MoveToFront(el)
+	e := el.Value.(*memEntry)
+	e.accessedAt = time.Now().UTC()
 	return io.NopCloser(bytes.NewReader(e.b)), e.meta, nil
 }
+
+func (c *memCache) Entries() ([]Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Entry, 0, len(c.data))
+	for url, el := range c.data {
+		e := el.Value.(*memEntry)
+		en := entryOf(url, e.meta, int64(len(e.b)), c.ttl)
+		en.AccessedAt = e.accessedAt
+		out = append(out, en)
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+func (c *memCache) Remove(url string) error {
+	c.mu.Lock()
+	c.remove(url)
+	c.mu.Unlock()
+	return nil
+}
+
+// remove drops url; c.mu must be held.
+func (c *memCache) remove(url string) {
+	el, ok := c.data[url]
+	if !ok {
+		return
+	}
+	c.bytes -= int64(len(el.Value.(*memEntry).b))
+	c.lru.Remove(el)
+	delete(c.data, url)
+}
+
+func (c *memCache) Touch(url string, meta Metadata) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.data[url]
+	if !ok {
+		return fmt.Errorf("%s: not cached", url)
+	}
+	e := el.Value.(*memEntry)
+	e.meta = revalidated(e.meta, meta)
+	return nil
+}
+
+func (c *memCache) Volatile() bool { return true }
+
+func (c *memCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats.snapshot(len(c.data), c.bytes)
+}
@@ -10,6 +10,7 @@ import (
 // - ETag:           from "ETag" header (stripped of weak/quotes as-is preserved)
 // - Last-Modified:  parsed via http.ParseTime
 // - ContentLength:  from resp.ContentLength or "Content-Length" header
+// - ContentEncoding: from "Content-Encoding" header (absent when net/http decoded it)
 func ParseRespMeta(resp *http.Response) Metadata {
 	var m Metadata
 
@@ -35,5 +36,7 @@ func ParseRespMeta(resp *http.Response) Metadata {
 		}
 	}
 
+	m.ContentEncoding = strings.TrimSpace(resp.Header.Get("Content-Encoding"))
+
 	return m
 }
@@ -0,0 +1,115 @@
+package download
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// Partials is implemented by caches that can hold on to an interrupted
+// download, so the next attempt resumes it with a Range request instead of
+// starting over. A download is written to a partial file as it streams, and
+// the finished file becomes the cache entry without being copied. The
+// filesystem cache keeps them next to its entries.
+type Partials interface {
+	// Partial reports the partial download kept for url: the number of bytes
+	// received and the validator (strong ETag or Last-Modified) of the
+	// response they came from.
+	Partial(url string) (size int64, validator string, ok bool)
+	// OpenPartial opens the bytes received so far for url.
+	OpenPartial(url string) (io.ReadCloser, error)
+	// WritePartial returns a writer for a download of url's version
+	// validator. Offset 0 starts over; anything else continues the partial
+	// download kept for url, which must have that size, and takes it over.
+	// Concurrent writers for url (in this process or another sharing the
+	// cache) must not disturb each other.
+	WritePartial(url, validator string, offset int64) (PartialWriter, error)
+	// DropPartial discards url's partial download, if any.
+	DropPartial(url string) error
+}
+
+// PartialWriter receives one download as it streams (see
+// Partials.WritePartial).
+type PartialWriter interface {
+	io.Writer
+	// Commit makes the completed download url's cache entry, with meta as
+	// Store would record it.
+	Commit(meta Metadata) error
+	// Abort ends an unfinished download, keeping it as url's partial
+	// download when keep is set.
+	Abort(keep bool) error
+}
+
+// partial is a download in progress, as reported by the cache.
+type partial struct {
+	size      int64
+	validator string
+}
+
+func (c *Client) partial(url string) partial {
+	if p, ok := c.cache.(Partials); ok {
+		if n, v, ok := p.Partial(url); ok && n > 0 && v != "" {
+			return partial{size: n, validator: v}
+		}
+	}
+	return partial{}
+}
+
+func (c *Client) dropPartial(url string) {
+	if p, ok := c.cache.(Partials); ok {
+		_ = p.DropPartial(url)
+	}
+}
+
+// truncated reports a body of got bytes where total were expected (-1 when
+// the server didn't say).
+func truncated(url string, total, got int64) error {
+	want := "the complete body"
+	if total >= 0 {
+		want = strconv.FormatInt(total, 10) + " bytes"
+	}
+	return &errs.IntegrityError{URL: url, Check: "content-length", Want: want, Got: strconv.FormatInt(got, 10) + " bytes"}
+}
+
+// resumeValidator returns the If-Range value that can resume resp: its
+// ETag if strong, else its Last-Modified. Bodies the transport decompressed
+// on the fly are not resumable, since their byte offsets aren't the
+// server's.
+func resumeValidator(resp *http.Response) string {
+	if resp.Uncompressed {
+		return ""
+	}
+	if et := strings.TrimSpace(resp.Header.Get("ETag")); et != "" && !strings.HasPrefix(et, "W/") {
+		return et
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// parseContentRange parses "bytes <start>-<end>/<size>"; size is -1 when
+// the server sends "*".
+func parseContentRange(s string) (start, size int64, ok bool) {
+	rng, ok := strings.CutPrefix(s, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	rng, total, ok := strings.Cut(rng, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	first, _, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if total == "*" {
+		return start, -1, true
+	}
+	size, err = strconv.ParseInt(total, 10, 64)
+	return start, size, err == nil
+}
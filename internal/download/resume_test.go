@@ -0,0 +1,161 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// rangeServer serves body under etag with Range/If-Range support
+// (http.ServeContent). While drop is set, full responses break off halfway.
+type rangeServer struct {
+	mu     sync.Mutex
+	body   []byte
+	etag   string
+	drop   bool
+	ranges []string // Range header of each request
+}
+
+func (s *rangeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	body, etag, drop := s.body, s.etag, s.drop
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", etag)
+	if drop && r.Header.Get("Range") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body[:len(body)/2]) // the server closes the short connection
+		return
+	}
+	http.ServeContent(w, r, "x.parquet", time.Time{}, bytes.NewReader(body))
+}
+
+func TestFetch_ResumesPartialDownload(t *testing.T) {
+	body := append([]byte("PAR1"), bytes.Repeat([]byte("0123456789"), 100)...)
+	rs := &rangeServer{body: body, etag: `"v1"`, drop: true}
+	srv := httptest.NewServer(rs)
+	defer srv.Close()
+	dir := t.TempDir()
+	cache := NewFSCache(dir, time.Hour)
+	c := New(WithCache(cache))
+	url := srv.URL + "/pbp.parquet"
+
+	err := fetchErr(context.Background(), c, url)
+	if !errors.Is(err, errs.ErrIntegrity) {
+		t.Fatalf("dropped download: err = %v, want a content-length integrity error", err)
+	}
+	if n, v, ok := cache.(Partials).Partial(url); !ok || n != int64(len(body)/2) || v != `"v1"` {
+		t.Fatalf("partial download: %d bytes of %s (%v)", n, v, ok)
+	}
+
+	got, meta := fetchString(t, c, url)
+	if got != string(body) || meta.Status != CacheDownloaded {
+		t.Fatalf("resumed body %d bytes (want %d), status %v", len(got), len(body), meta.Status)
+	}
+	if want := "bytes=" + strconv.Itoa(len(body)/2) + "-"; rs.ranges[1] != want {
+		t.Fatalf("resume request Range = %q, want %q", rs.ranges[1], want)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(dir, "*.part*")); len(parts) != 0 {
+		t.Fatalf("partial files left behind: %v", parts)
+	}
+}
+
+func TestFetch_ConcurrentClientsShareCacheDir(t *testing.T) {
+	body := append([]byte("PAR1"), bytes.Repeat([]byte("0123456789"), 10000)...)
+	const n = 2
+	var (
+		halfway   sync.WaitGroup
+		requests  atomic.Int32
+		first     = make(chan struct{}) // closed once one download is done
+		firstOnce sync.Once
+	)
+	halfway.Add(n)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last := requests.Add(1) == n
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body[:len(body)/2])
+		w.(http.Flusher).Flush()
+		halfway.Done()
+		halfway.Wait() // both downloads are writing partial files now
+		if last {
+			<-first // finish after the other download was committed
+		}
+		w.Write(body[len(body)/2:])
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	url := srv.URL + "/pbp.parquet"
+
+	// Separate clients (as in separate processes) don't coalesce.
+	fails := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fails[i] = fetchErr(context.Background(), New(WithCache(NewFSCache(dir, time.Hour))), url)
+			firstOnce.Do(func() { close(first) })
+		}()
+	}
+	wg.Wait()
+	for i, err := range fails {
+		if err != nil {
+			t.Fatalf("client %d: %v", i, err)
+		}
+	}
+	got, meta := fetchString(t, New(WithCache(NewFSCache(dir, time.Hour))), url)
+	if got != string(body) || meta.Status != CacheFresh {
+		t.Fatalf("cached body is %d bytes (want %d), status %v", len(got), len(body), meta.Status)
+	}
+	if parts, _ := filepath.Glob(filepath.Join(dir, "*.part*")); len(parts) != 0 {
+		t.Fatalf("partial files left behind: %v", parts)
+	}
+}
+
+func TestFetch_ResumeFallsBackWhenETagChanges(t *testing.T) {
+	old := append([]byte("PAR1"), bytes.Repeat([]byte("a"), 1000)...)
+	rs := &rangeServer{body: old, etag: `"v1"`, drop: true}
+	srv := httptest.NewServer(rs)
+	defer srv.Close()
+	c := New(WithCache(NewFSCache(t.TempDir(), time.Hour)))
+	url := srv.URL + "/pbp.parquet"
+
+	if err := fetchErr(context.Background(), c, url); err == nil {
+		t.Fatal("dropped download succeeded")
+	}
+	rs.mu.Lock()
+	rs.body, rs.etag, rs.drop = append([]byte("PAR1"), bytes.Repeat([]byte("b"), 800)...), `"v2"`, false
+	rs.mu.Unlock()
+
+	if got, _ := fetchString(t, c, url); got != string(rs.body) {
+		t.Fatalf("after upstream change got %d bytes starting %q; want the new file whole", len(got), got[:8])
+	}
+	if rs.ranges[1] == "" {
+		t.Fatal("second request did not try to resume")
+	}
+}
+
+func TestFetch_ResumesWithinRetryBudget(t *testing.T) {
+	body := append([]byte("PAR1"), bytes.Repeat([]byte("z"), 999)...)
+	rs := &rangeServer{body: body, etag: `"v1"`, drop: true}
+	srv := httptest.NewServer(rs)
+	defer srv.Close()
+	c := New(WithCache(NewFSCache(t.TempDir(), time.Hour)), WithRetry(RetryPolicy{MaxAttempts: 3}))
+
+	got, _ := fetchString(t, c, srv.URL+"/pbp.parquet")
+	if got != string(body) || len(rs.ranges) != 2 || rs.ranges[1] != "bytes=501-" {
+		t.Fatalf("got %d bytes with requests %q; want %d bytes, resumed at byte 501", len(got), rs.ranges, len(body))
+	}
+}
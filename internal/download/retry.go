@@ -0,0 +1,134 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how Fetch retries transient failures. Fetch only
+// issues GETs, so every attempt is idempotent; retries are limited to
+// failures that are likely to clear on their own: 408/429/5xx gateway
+// statuses, connection resets, and per-attempt timeouts. Cancellation of the
+// caller's ctx is never retried.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // delay before the 2nd attempt; doubles each retry
+	MaxDelay    time.Duration // cap for any single delay, including Retry-After (0 = no cap)
+	Jitter      float64       // 0..1: fraction of each delay that is randomized
+}
+
+// DefaultRetryPolicy retries up to 3 times (4 attempts) starting at 500ms,
+// capped at 30s, with 50% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second, Jitter: 0.5}
+}
+
+// Attempt describes one HTTP attempt made by Fetch, reported to the
+// WithRetryHook callback.
+type Attempt struct {
+	URL    string
+	N      int           // 1-based attempt number
+	Status int           // HTTP status, 0 when the request failed outright
+	Err    error         // transport error, if any
+	Retry  bool          // whether Fetch will try again
+	Delay  time.Duration // wait before the next attempt (when Retry)
+}
+
+// WithRetry enables retries according to p.
+func WithRetry(p RetryPolicy) Option { return func(c *Client) { c.retry = p } }
+
+// WithRetryHook registers fn to be called after every attempt, including the
+// final one. fn must be safe for concurrent use if the Client is shared.
+func WithRetryHook(fn func(Attempt)) Option { return func(c *Client) { c.onAttempt = fn } }
+
+// retryable reports whether an attempt's outcome is transient. ctx is the
+// caller's context: once it is done, nothing is retried.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var ne net.Error
+		switch {
+		case errors.As(err, &ne) && ne.Timeout():
+			return true
+		case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED),
+			errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+			return true
+		}
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns the wait after attempt n (1-based). A Retry-After header on
+// resp takes precedence over the exponential backoff.
+func (p RetryPolicy) delay(n int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return p.cap(d)
+	}
+	d := p.BaseDelay << (n - 1)
+	if d <= 0 { // overflow
+		d = p.MaxDelay
+	}
+	d = p.cap(d)
+	if p.Jitter > 0 {
+		j := min(p.Jitter, 1)
+		d = time.Duration(float64(d) * (1 - j + j*rand.Float64()))
+	}
+	return d
+}
+
+func (p RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// retryAfter parses Retry-After as delta-seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetry(n int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: n, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+}
+
+func TestFetch_RetriesTransientStatus(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch hits.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			io.WriteString(w, "ok")
+		}
+	}))
+	defer srv.Close()
+
+	var seen []Attempt
+	c := New(WithRetry(fastRetry(4)), WithRetryHook(func(a Attempt) { seen = append(seen, a) }))
+	rc, meta, err := c.Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "ok" || meta.Attempts != 3 {
+		t.Fatalf("body=%q attempts=%d, want ok/3", b, meta.Attempts)
+	}
+	if len(seen) != 3 || seen[0].Status != 429 || seen[0].Delay != 0 || !seen[1].Retry || seen[2].Retry {
+		t.Fatalf("hook saw %+v", seen)
+	}
+}
+
+func TestFetch_NoRetryOnNotFound(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, _, err := New(WithRetry(fastRetry(4))).Fetch(context.Background(), srv.URL)
+	var he *HTTPError
+	if !errors.As(err, &he) || he.Code != 404 || hits.Load() != 1 {
+		t.Fatalf("err=%v hits=%d, want one 404", err, hits.Load())
+	}
+}
+
+func TestFetch_GivesUpAfterMaxAttempts(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, _, err := New(WithRetry(fastRetry(3))).Fetch(context.Background(), srv.URL)
+	var he *HTTPError
+	if !errors.As(err, &he) || he.Code != 503 || hits.Load() != 3 {
+		t.Fatalf("err=%v hits=%d, want 503 after 3 attempts", err, hits.Load())
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	if d := p.delay(3, nil); d != 400*time.Millisecond {
+		t.Errorf("delay(3) = %v, want 400ms", d)
+	}
+	if d := p.delay(10, nil); d != time.Second {
+		t.Errorf("delay(10) = %v, want capped 1s", d)
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": {"5"}}}
+	if d := p.delay(1, resp); d != time.Second {
+		t.Errorf("Retry-After delay = %v, want capped 1s", d)
+	}
+	p.Jitter = 0.5
+	for range 20 {
+		if d := p.delay(1, nil); d < 50*time.Millisecond || d > 100*time.Millisecond {
+			t.Fatalf("jittered delay %v outside [50ms,100ms]", d)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// S3Options configures NewS3Cache.
+type S3Options struct {
+	// Endpoint is the service base URL, e.g. "https://s3.us-east-1.amazonaws.com",
+	// "https://<account>.r2.cloudflarestorage.com" or "http://localhost:9000".
+	// Requests use path-style addressing: <Endpoint>/<Bucket>/<key>.
+	Endpoint string
+	Bucket   string
+	Prefix   string // key prefix, e.g. "nflreadgo/"
+	Region   string // signing region; "us-east-1" when empty
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional, for temporary credentials
+
+	TTL time.Duration
+	// Timeout bounds each request, including reading its body; 2 minutes
+	// when zero.
+	Timeout    time.Duration
+	HTTPClient *http.Client // a client with Timeout when nil
+}
+
+const defaultS3Timeout = 2 * time.Minute
+
+type s3Cache struct {
+	o     S3Options
+	creds credentials
+	now   func() time.Time
+	stats counters
+}
+
+// NewS3Cache returns a Cache backed by an S3-compatible bucket (AWS S3,
+// MinIO, Cloudflare R2, ...), so several machines can share downloaded
+// assets. Entries use the filesystem cache's layout: <hash>.data objects
+// with <hash>.json sidecars that double as the key index. Requests are
+// signed with AWS Signature Version 4.
+//
+// Every operation is a network round trip; put a memory or disk cache in
+// front of it with NewLayeredCache.
+func NewS3Cache(o S3Options) Cache {
+	if o.Region == "" {
+		o.Region = "us-east-1"
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultS3Timeout
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: o.Timeout}
+	}
+	o.Endpoint = strings.TrimSuffix(o.Endpoint, "/")
+	return &s3Cache{
+		o:     o,
+		creds: credentials{o.AccessKeyID, o.SecretAccessKey, o.SessionToken, o.Region},
+		now:   time.Now,
+	}
+}
+
+func (c *s3Cache) key(url, ext string) string {
+	return c.o.Prefix + hashKey(url) + ext
+}
+
+// do sends a signed request for key (an object key, or "" for the bucket).
+// The request is bounded by the Timeout, which runs until the response
+// body is closed.
+func (c *s3Cache) do(method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u := c.o.Endpoint + "/" + c.o.Bucket
+	if key != "" {
+		u += "/" + (&url.URL{Path: key}).EscapedPath()
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.o.Timeout)
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	hash := emptySHA256
+	if len(body) > 0 {
+		hash = sha256Hex(body)
+	}
+	c.creds.sign(req, hash, c.now())
+	resp, err := c.o.HTTPClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{resp.Body, cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a request's context once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// get fetches an object, returning os.ErrNotExist for a missing key.
+func (c *s3Cache) get(key string) (io.ReadCloser, error) {
+	resp, err := c.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp, key)
+	}
+	return resp.Body, nil
+}
+
+func (c *s3Cache) put(key string, body []byte) error {
+	resp, err := c.do(http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return s3Error(resp, key)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *s3Cache) delete(key string) error {
+	resp, err := c.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return s3Error(resp, key)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func s3Error(resp *http.Response, key string) error {
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("s3 %s: %w", key, os.ErrNotExist)
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	return fmt.Errorf("s3 %s: %s: %s", key, resp.Status, strings.TrimSpace(string(b)))
+}
+
+func (c *s3Cache) readMeta(url string) (sidecar, error) {
+	rc, err := c.get(c.key(url, ".json"))
+	if err != nil {
+		return sidecar{}, err
+	}
+	defer rc.Close()
+	var sc sidecar
+	if err := json.NewDecoder(rc).Decode(&sc); err != nil {
+		return sidecar{}, err
+	}
+	return sc, nil
+}
+
+func (c *s3Cache) writeMeta(url string, sc sidecar) error {
+	j, err := json.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	return c.put(c.key(url, ".json"), j)
+}
+
+// Lookup treats any failure to read the sidecar (including network errors)
+// as a miss, so an unreachable bucket degrades to downloading upstream.
+func (c *s3Cache) Lookup(url string) (Metadata, bool, bool) {
+	sc, err := c.readMeta(url)
+	if err != nil {
+		c.stats.miss()
+		return Metadata{}, false, false
+	}
+	fresh := !expired(sc.SavedAt, c.o.TTL)
+	c.stats.lookup(fresh)
+	return sc.meta(), fresh, true
+}
+
+func (c *s3Cache) Open(url string) (io.ReadCloser, Metadata, error) {
+	sc, err := c.readMeta(url)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	rc, err := c.get(c.key(url, ".data"))
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	return rc, sc.meta(), nil
+}
+
+// Store uploads the body first and the sidecar second, so readers never
+// find a sidecar pointing at a missing or partial object.
+func (c *s3Cache) Store(url string, m Metadata, body io.Reader) (io.ReadCloser, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if m.SHA256 == "" {
+		m.SHA256 = hashOf(b)
+	}
+	if err := c.put(c.key(url, ".data"), b); err != nil {
+		return nil, err
+	}
+	if err := c.writeMeta(url, sidecarOf(url, m, int64(len(b)))); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (c *s3Cache) Touch(url string, m Metadata) error {
+	sc, err := c.readMeta(url)
+	if err != nil {
+		return err
+	}
+	rm := revalidated(sc.meta(), m)
+	sc.ETag, sc.LastModified, sc.SavedAt = rm.ETag, rm.LastModified, rm.SavedAt
+	return c.writeMeta(url, sc)
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// Entries lists the bucket prefix (ListObjectsV2) and reads each sidecar.
+func (c *s3Cache) Entries() ([]Entry, error) {
+	var out []Entry
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {c.o.Prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		resp, err := c.do(http.MethodGet, "", q, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, s3Error(resp, c.o.Prefix)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			if !strings.HasSuffix(obj.Key, ".json") {
+				continue
+			}
+			rc, err := c.get(obj.Key)
+			if errors.Is(err, os.ErrNotExist) {
+				continue // removed concurrently
+			}
+			if err != nil {
+				return nil, err
+			}
+			var sc sidecar
+			err = json.NewDecoder(rc).Decode(&sc)
+			rc.Close()
+			if err != nil || sc.URL == "" {
+				continue
+			}
+			out = append(out, entryOf(sc.URL, sc.meta(), sc.Size, c.o.TTL))
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+	sortEntries(out)
+	return out, nil
+}
+
+func (c *s3Cache) Remove(url string) error {
+	if err := c.delete(c.key(url, ".json")); err != nil {
+		return err
+	}
+	return c.delete(c.key(url, ".data"))
+}
+
+// Stats reports this process's hit/miss counters; Entries and Bytes are
+// left zero to avoid listing the bucket.
+func (c *s3Cache) Stats() CacheStats {
+	return c.stats.snapshot(0, 0)
+}
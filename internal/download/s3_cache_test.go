@@ -0,0 +1,174 @@
+package download
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// fakeS3 is a local stand-in for an S3-compatible service: path-style
+// buckets, GET/PUT/DELETE objects, ListObjectsV2, and SigV4 checking.
+type fakeS3 struct {
+	creds   credentials
+	mu      sync.Mutex
+	objects map[string][]byte // "bucket/key"
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	// Re-sign the request as received and compare signatures.
+	check := r.Clone(context.Background())
+	check.Header.Del("Authorization")
+	check.Host = r.Host
+	when, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil || r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+		http.Error(w, "bad date or payload hash", http.StatusBadRequest)
+		return
+	}
+	f.creds.sign(check, sha256Hex(body), when)
+	if check.Header.Get("Authorization") != r.Header.Get("Authorization") {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		type content struct{ Key string }
+		var res struct {
+			XMLName  xml.Name `xml:"ListBucketResult"`
+			Contents []content
+		}
+		prefix := path + "/" + r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, strings.TrimPrefix(k, path+"/"))
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			res.Contents = append(res.Contents, content{k})
+		}
+		xml.NewEncoder(w).Encode(res)
+	case r.Method == http.MethodGet:
+		b, ok := f.objects[path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	case r.Method == http.MethodPut:
+		f.objects[path] = body
+	case r.Method == http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, S3Options) {
+	f := &fakeS3{creds: credentials{"AK", "SECRET", "", "us-east-1"}, objects: map[string][]byte{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, S3Options{Endpoint: srv.URL, Bucket: "nfl", Prefix: "cache/", AccessKeyID: "AK", SecretAccessKey: "SECRET", TTL: time.Hour}
+}
+
+func TestS3Cache(t *testing.T) {
+	fake, opts := newFakeS3(t)
+	origin, hits, _ := etagServer(t, "a,b\n1,2\n")
+	s3 := NewS3Cache(opts)
+
+	ctx := WithAsset(context.Background(), "pbp", 2024)
+	if err := fetchErr(ctx, New(WithCache(s3)), origin.URL+"/pbp_2024.csv"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.objects) != 2 {
+		t.Fatalf("objects = %d, want data + sidecar", len(fake.objects))
+	}
+
+	// Another machine with the same bucket is served without the origin.
+	if b, meta := fetchString(t, New(WithCache(NewS3Cache(opts))), origin.URL+"/pbp_2024.csv"); b != "a,b\n1,2\n" || meta.Attempts != 0 || hits.Load() != 1 {
+		t.Fatalf("shared fetch = %q, attempts %d, origin hits %d", b, meta.Attempts, hits.Load())
+	}
+
+	es, err := Entries(s3)
+	if err != nil || len(es) != 1 || es[0].Name() != "pbp/2024" || es[0].Size != 8 {
+		t.Fatalf("Entries = %+v, %v", es, err)
+	}
+	if n, err := Clear(s3, "pbp"); err != nil || n != 1 || len(fake.objects) != 0 {
+		t.Fatalf("Clear = %d, %v (objects left %d)", n, err, len(fake.objects))
+	}
+
+	bad := opts
+	bad.SecretAccessKey = "wrong"
+	if _, err := NewS3Cache(bad).Store("u", Metadata{}, strings.NewReader("x")); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("Store with a bad key: err = %v, want 403", err)
+	}
+}
+
+func TestS3Cache_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // a service that never answers
+	}))
+	defer srv.Close()
+	s3 := NewS3Cache(S3Options{Endpoint: srv.URL, Bucket: "nfl", Timeout: 50 * time.Millisecond})
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := s3.Open("u")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Open of a hung service succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Open of a hung service didn't time out")
+	}
+}
+
+func TestLayeredCache(t *testing.T) {
+	_, opts := newFakeS3(t)
+	remote := NewS3Cache(opts)
+	origin, hits, _ := etagServer(t, "x,y\n")
+	url := origin.URL + "/a.csv"
+
+	// Machine A fills every tier.
+	fetchString(t, New(WithCache(NewLayeredCache(NewMemCache(time.Hour), NewFSCache(t.TempDir(), time.Hour), remote))), url)
+
+	// Machine B starts cold locally: served from the remote tier and
+	// promoted into its memory and disk tiers.
+	mem, disk := NewMemCache(time.Hour), NewFSCache(t.TempDir(), time.Hour)
+	b, meta := fetchString(t, New(WithCache(NewLayeredCache(mem, disk, remote))), url)
+	if b != "x,y\n" || meta.Attempts != 0 || hits.Load() != 1 {
+		t.Fatalf("machine B: %q attempts %d origin hits %d", b, meta.Attempts, hits.Load())
+	}
+	for name, c := range map[string]Cache{"mem": mem, "disk": disk} {
+		if _, fresh, ok := c.Lookup(url); !ok || !fresh {
+			t.Fatalf("%s tier not populated", name)
+		}
+	}
+}
+
+func TestFixtureCache(t *testing.T) {
+	fsys := fstest.MapFS{"example.com/data/a.csv": {Data: []byte("season\n2024\n")}}
+	c := New(WithCache(NewFixtureCache(fsys)), WithOffline(true))
+	if b, _ := fetchString(t, c, "https://example.com/data/a.csv?raw=1"); b != "season\n2024\n" {
+		t.Fatalf("fixture body = %q", b)
+	}
+	if _, _, err := c.Fetch(context.Background(), "https://example.com/data/b.csv"); err == nil {
+		t.Fatal("missing fixture served")
+	}
+}
@@ -0,0 +1,75 @@
+package download
+
+import "sync/atomic"
+
+// CacheOption configures NewMemCache and NewFSCache.
+type CacheOption func(*cacheOpts)
+
+type cacheOpts struct {
+	maxBytes int64
+}
+
+// WithMaxBytes caps the total size of cached bodies; when a store exceeds
+// it, least recently used entries are evicted. n <= 0 means unbounded.
+func WithMaxBytes(n int64) CacheOption {
+	return func(o *cacheOpts) { o.maxBytes = max(n, 0) }
+}
+
+func cacheOptions(opts []CacheOption) cacheOpts {
+	var o cacheOpts
+	for _, f := range opts {
+		f(&o)
+	}
+	return o
+}
+
+// CacheStats is a snapshot of a cache's counters. Hits are lookups that
+// found a fresh entry; misses found nothing or only an expired entry (which
+// may still be revalidated with a cheap 304). Counters cover the lifetime of
+// the cache value, not what is on disk from earlier processes.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // entries removed to stay within the byte limit
+	Entries   int
+	Bytes     int64
+}
+
+// Stater is implemented by caches that keep CacheStats; both built-in
+// caches do.
+type Stater interface {
+	Stats() CacheStats
+}
+
+// StatsOf returns c's stats, or false when c doesn't keep any.
+func StatsOf(c Cache) (CacheStats, bool) {
+	s, ok := c.(Stater)
+	if !ok {
+		return CacheStats{}, false
+	}
+	return s.Stats(), true
+}
+
+type counters struct {
+	hits, misses, evictions atomic.Uint64
+}
+
+func (c *counters) miss() { c.misses.Add(1) }
+
+func (c *counters) lookup(fresh bool) {
+	if fresh {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+func (c *counters) snapshot(entries int, bytes int64) CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+		Bytes:     bytes,
+	}
+}
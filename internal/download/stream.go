@@ -0,0 +1,286 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// errAbandoned ends a download whose body was closed before it was read to
+// the end; nothing is cached, and callers waiting on it download it
+// themselves.
+var errAbandoned = errors.New("download abandoned before EOF")
+
+// body is a download as Fetch hands it to the caller. It reads the
+// response (after the partial download a 206 continues), feeds each chunk
+// to the cache as it goes, and checks the whole once it ends: the cache
+// keeps it only if the length and checksum match. It is not safe for
+// concurrent use.
+type body struct {
+	c    *Client
+	ctx  context.Context
+	url  string
+	meta Metadata // as returned by Fetch; SHA256 is filled in at the end
+
+	resp   *http.Response
+	prefix io.ReadCloser // the partial download a 206 continues, if any
+	src    io.Reader
+
+	sink sink
+	skip int64 // leading bytes the sink already has
+
+	n         int64  // bytes read so far, including prefix
+	total     int64  // expected length; -1 when unknown
+	validator string // If-Range value to resume with; "" if not resumable
+	want      string // expected SHA-256, if any
+	hash      hash.Hash
+	attempts  int
+
+	err    error // what Read returns once the body has ended
+	onDone func(error)
+}
+
+// stream returns the body of a 200 or 206 response for url. It rejects
+// error pages before the caller sees a byte, and a 206 that doesn't
+// continue part where it left off; prefix holds part's bytes.
+func (c *Client) stream(ctx context.Context, url string, resp *http.Response, meta Metadata, part partial, prefix io.ReadCloser) (*body, error) {
+	b := &body{
+		c: c, ctx: ctx, url: url, meta: meta,
+		resp: resp, prefix: prefix, src: resp.Body,
+		total:     resp.ContentLength,
+		validator: resumeValidator(resp),
+		want:      checksumFrom(ctx),
+		hash:      sha256.New(),
+		attempts:  meta.Attempts,
+	}
+	var head []byte
+	offset := int64(0)
+	if resp.StatusCode == http.StatusPartialContent {
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != part.size {
+			b.close()
+			c.dropPartial(url)
+			return nil, &errs.IntegrityError{URL: url, Check: "content-range", Want: fmt.Sprintf("bytes %d-", part.size), Got: resp.Header.Get("Content-Range")}
+		}
+		b.total, offset = size, start
+		if prefix != nil {
+			b.src = io.MultiReader(prefix, resp.Body)
+		}
+	} else {
+		// Look at the first chunk, however small, without waiting for more.
+		head = make([]byte, 512)
+		n, _ := io.ReadAtLeast(resp.Body, head, 1)
+		head = head[:n]
+		b.src = io.MultiReader(bytes.NewReader(head), resp.Body)
+	}
+	if _, ok := FormatOfPath(url); ok {
+		if what := errorPage(resp.Header.Get("Content-Type"), head); what != "" {
+			b.close()
+			return nil, &errs.IntegrityError{URL: url, Check: "content", Want: "data", Got: what}
+		}
+	}
+	if b.total >= 0 {
+		b.meta.ContentLength = b.total
+	}
+	b.sink, b.skip = c.sinkFor(url, b.meta, b.validator, offset)
+	return b, nil
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	n, err := b.src.Read(p)
+	if n > 0 {
+		b.hash.Write(p[:n])
+		if b.sink != nil && b.n+int64(n) > b.skip {
+			if _, werr := b.sink.Write(p[max(b.skip-b.n, 0):n]); werr != nil {
+				b.n += int64(n)
+				return n, b.end(fmt.Errorf("caching %s: %w", b.url, werr), true)
+			}
+		}
+		b.n += int64(n)
+	}
+	switch {
+	case err == io.EOF:
+		return n, b.end(b.check(), false)
+	case err != nil && b.resume():
+		return n, nil
+	case err != nil:
+		return n, b.end(b.broken(err), true)
+	}
+	return n, nil
+}
+
+// Close ends the download. A body closed before EOF is not cached (a
+// resumable one stays a partial download), unless every expected byte was
+// already read.
+func (b *body) Close() error {
+	if b.err == nil && b.total >= 0 && b.n == b.total {
+		var buf [1]byte
+		b.Read(buf[:]) // only the EOF is left
+	}
+	if b.err == nil {
+		b.end(errAbandoned, true)
+	}
+	return nil
+}
+
+// check verifies the complete body: its length against Content-Length (or
+// a 206's Content-Range total) and its SHA-256 against the expected digest.
+func (b *body) check() error {
+	if b.total >= 0 && b.n != b.total {
+		return truncated(b.url, b.total, b.n)
+	}
+	sum := hex.EncodeToString(b.hash.Sum(nil))
+	if b.want != "" && sum != b.want {
+		return &errs.IntegrityError{URL: b.url, Check: "sha256", Want: b.want, Got: sum}
+	}
+	b.meta.SHA256, b.meta.ContentLength = sum, b.n
+	return nil
+}
+
+// broken describes a transfer that failed with err before the end.
+func (b *body) broken(err error) error {
+	if cerr := b.ctx.Err(); cerr != nil {
+		return cerr
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return truncated(b.url, b.total, b.n)
+	}
+	return fmt.Errorf("%w: %w", errs.ErrUpstreamUnavailable, err)
+}
+
+// resume continues a transfer that broke off with a Range request from
+// the current offset, while the retry policy has attempts left and the
+// response can be resumed. It reports whether the body goes on.
+func (b *body) resume() bool {
+	if b.validator == "" || b.n == 0 || b.attempts >= b.c.retry.MaxAttempts || b.ctx.Err() != nil {
+		return false
+	}
+	_ = b.resp.Body.Close()
+	resp, tries, err := b.c.get(b.ctx, b.url, nil, partial{size: b.n, validator: b.validator})
+	b.attempts += tries
+	if err != nil {
+		return false
+	}
+	if start, _, ok := parseContentRange(resp.Header.Get("Content-Range")); resp.StatusCode != http.StatusPartialContent || !ok || start != b.n {
+		_ = resp.Body.Close()
+		return false
+	}
+	b.resp, b.src = resp, resp.Body
+	return true
+}
+
+// end finishes the body: a nil err commits it to the cache, anything else
+// discards it (keeping a partial download when keep is set). It returns
+// what Read reports from then on.
+func (b *body) end(err error, keep bool) error {
+	if b.sink != nil {
+		if err == nil {
+			if cerr := b.sink.commit(b.meta); cerr != nil {
+				err = fmt.Errorf("caching %s: %w", b.url, cerr)
+			}
+		} else {
+			b.sink.abort(err, keep)
+		}
+	}
+	if err == nil {
+		b.c.dropPartial(b.url) // superseded, if one was left from another version
+	}
+	b.close()
+	b.err = err
+	if err == nil {
+		b.err = io.EOF
+	}
+	if b.onDone != nil {
+		b.onDone(err)
+	}
+	return b.err
+}
+
+func (b *body) close() {
+	_ = b.resp.Body.Close()
+	if b.prefix != nil {
+		_ = b.prefix.Close()
+	}
+}
+
+// sink receives a download as it streams to the caller and keeps it once
+// the body checks out.
+type sink interface {
+	io.Writer
+	commit(meta Metadata) error
+	abort(err error, keep bool)
+}
+
+// sinkFor picks where a download of url goes as it streams: a partial
+// download when the cache keeps them and the response can be resumed (the
+// sink then already has the first offset bytes), else a Store of the whole
+// body. It returns nil without a cache.
+func (c *Client) sinkFor(url string, meta Metadata, validator string, offset int64) (sink, int64) {
+	if c.cache == nil {
+		return nil, 0
+	}
+	if p, ok := c.cache.(Partials); ok && validator != "" {
+		if w, err := p.WritePartial(url, validator, offset); err == nil {
+			return partialSink{w}, offset
+		}
+	}
+	return c.startStore(url, meta), 0
+}
+
+// storeSink pipes a download into Cache.Store, which runs alongside the
+// caller's reads. The body only ends (and Store only returns) once the
+// download has been checked, so a rejected body never becomes an entry.
+type storeSink struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+}
+
+func (c *Client) startStore(url string, meta Metadata) *storeSink {
+	pr, pw := io.Pipe()
+	s := &storeSink{pw: pw, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		rc, err := c.cache.Store(url, meta, pr)
+		if err == nil {
+			rc.Close()
+		}
+		s.err = err
+		pr.CloseWithError(err) // a Store that failed early fails the writes
+	}()
+	return s
+}
+
+func (s *storeSink) Write(p []byte) (int, error) { return s.pw.Write(p) }
+
+func (s *storeSink) commit(Metadata) error {
+	s.pw.Close()
+	<-s.done
+	return s.err
+}
+
+func (s *storeSink) abort(err error, _ bool) {
+	s.pw.CloseWithError(err)
+	<-s.done
+}
+
+// partialSink writes a download to a partial file of the cache and
+// commits that file as the entry.
+type partialSink struct {
+	PartialWriter
+}
+
+func (s partialSink) commit(meta Metadata) error { return s.Commit(meta) }
+
+func (s partialSink) abort(_ error, keep bool) { _ = s.Abort(keep) }
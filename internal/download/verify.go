@@ -0,0 +1,110 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+type checksumKey struct{}
+
+// WithChecksum returns a ctx whose Fetch verifies the downloaded body
+// against digest, a SHA-256 in hex, optionally prefixed "sha256:" (the form
+// GitHub release manifests use). Digests in other algorithms are ignored.
+// Only downloads are checked, as their body ends (see Fetch); cached copies
+// are checked against the hash recorded when they were stored.
+func WithChecksum(ctx context.Context, digest string) context.Context {
+	return context.WithValue(ctx, checksumKey{}, digest)
+}
+
+// checksumFrom returns the expected SHA-256 (lowercase hex) set on ctx, if any.
+func checksumFrom(ctx context.Context) string {
+	d, _ := ctx.Value(checksumKey{}).(string)
+	if algo, hexsum, ok := strings.Cut(d, ":"); ok {
+		if !strings.EqualFold(algo, "sha256") {
+			return ""
+		}
+		d = hexsum
+	}
+	return strings.ToLower(strings.TrimSpace(d))
+}
+
+// errorPage describes b if it looks like an HTML or JSON document (a
+// proxy, CDN or API error) rather than CSV/Parquet data, or returns "".
+func errorPage(contentType string, b []byte) string {
+	ct := strings.ToLower(contentType)
+	head := bytes.TrimLeft(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf")), " \t\r\n")
+	switch {
+	case strings.HasPrefix(ct, "text/html"), len(head) > 0 && head[0] == '<':
+		return "an HTML page"
+	case strings.Contains(ct, "json"), len(head) > 0 && (head[0] == '{' || head[0] == '['):
+		return "a JSON document"
+	}
+	return ""
+}
+
+// hashOf returns the SHA-256 of b in hex.
+func hashOf(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// openVerified opens the cached body for url and checks it against the
+// size and SHA-256 recorded when it was stored before handing it out, so a
+// caller never reads bytes that fail the check. A corrupt entry is removed
+// (when the cache supports it) and reported as an *errs.IntegrityError, and
+// Fetch downloads it again. Entries stored without a hash are trusted.
+//
+// Seekable bodies (files) are hashed and rewound; others are buffered.
+func (c *Client) openVerified(url string) (io.ReadCloser, Metadata, error) {
+	rc, meta, err := c.cache.Open(url)
+	if err != nil {
+		return nil, Metadata{}, err
+	}
+	if meta.SHA256 == "" {
+		return rc, meta, nil
+	}
+	if f, ok := rc.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if fi, err := f.Stat(); err == nil && fi.Size() != meta.ContentLength {
+			rc.Close()
+			c.remove(url)
+			return nil, Metadata{}, truncated(url, meta.ContentLength, fi.Size())
+		}
+	}
+
+	h := sha256.New()
+	body := rc
+	if s, ok := rc.(io.Seeker); ok {
+		if _, err = io.Copy(h, rc); err == nil {
+			_, err = s.Seek(0, io.SeekStart)
+		}
+	} else {
+		var buf bytes.Buffer
+		_, err = io.Copy(io.MultiWriter(h, &buf), rc)
+		rc.Close()
+		body = io.NopCloser(&buf)
+	}
+	if err != nil {
+		body.Close()
+		return nil, Metadata{}, err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != meta.SHA256 {
+		body.Close()
+		c.remove(url)
+		return nil, Metadata{}, &errs.IntegrityError{URL: url, Check: "sha256", Want: meta.SHA256, Got: sum}
+	}
+	return body, meta, nil
+}
+
+// remove drops the cache entry for url, when the cache supports it.
+func (c *Client) remove(url string) {
+	if ix, ok := c.cache.(Index); ok {
+		_ = ix.Remove(url)
+	}
+}
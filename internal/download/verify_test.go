@@ -0,0 +1,94 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+func TestFetch_RejectsBadBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short.csv":
+			w.Header().Set("Content-Length", "100")
+			io.WriteString(w, "a,b\n1,2\n")
+		case "/page.csv":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, "<!DOCTYPE html><html><body>Service unavailable</body></html>")
+		case "/api.parquet":
+			io.WriteString(w, `{"message":"Bad credentials"}`)
+		default:
+			io.WriteString(w, "a,b\n1,2\n")
+		}
+	}))
+	defer srv.Close()
+	cache := NewMemCache(time.Hour)
+	c := New(WithCache(cache))
+
+	for _, tc := range []struct{ path, check string }{
+		{"/short.csv", "content-length"},
+		{"/page.csv", "content"},
+		{"/api.parquet", "content"},
+	} {
+		err := fetchErr(context.Background(), c, srv.URL+tc.path)
+		var ie *errs.IntegrityError
+		if !errors.Is(err, errs.ErrIntegrity) || !errors.As(err, &ie) || ie.Check != tc.check {
+			t.Errorf("%s: err = %v, want %s integrity error", tc.path, err, tc.check)
+		}
+		if _, _, ok := cache.Lookup(srv.URL + tc.path); ok {
+			t.Errorf("%s: rejected body was cached", tc.path)
+		}
+	}
+
+	// Checksums apply when the caller has one.
+	ctx := WithChecksum(context.Background(), "sha256:"+hashOf([]byte("a,b\n1,2\n")))
+	if err := fetchErr(ctx, c, srv.URL+"/ok.csv"); err != nil {
+		t.Fatalf("matching checksum: %v", err)
+	}
+	ctx = WithChecksum(context.Background(), hashOf([]byte("something else")))
+	if err := fetchErr(ctx, c, srv.URL+"/other.csv"); !errors.Is(err, errs.ErrIntegrity) {
+		t.Fatalf("mismatched checksum: err = %v", err)
+	}
+	if _, _, ok := cache.Lookup(srv.URL + "/other.csv"); ok {
+		t.Fatal("body with a mismatched checksum was cached")
+	}
+}
+
+func TestFSCache_CorruptEntryRefetched(t *testing.T) {
+	for name, corrupt := range map[string]string{
+		"truncated": "a,b\n1,",
+		"same size": "a,b\n1,3\n", // only the hash can tell
+	} {
+		t.Run(name, func(t *testing.T) {
+			var hits atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				io.WriteString(w, "a,b\n1,2\n")
+			}))
+			defer srv.Close()
+			dir := t.TempDir()
+			c := New(WithCache(NewFSCache(dir, time.Hour)))
+			url := srv.URL + "/x.csv"
+
+			fetchString(t, c, url)
+			if err := os.WriteFile(filepath.Join(dir, hashKey(url)+".data"), []byte(corrupt), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if b, meta := fetchString(t, c, url); b != "a,b\n1,2\n" || meta.Status != CacheDownloaded || hits.Load() != 2 {
+				t.Fatalf("after corruption: body %q, status %v, hits %d; want a fresh download", b, meta.Status, hits.Load())
+			}
+			if b, meta := fetchString(t, c, url); b != "a,b\n1,2\n" || meta.Status != CacheFresh {
+				t.Fatalf("repaired entry: body %q, status %v", b, meta.Status)
+			}
+		})
+	}
+}
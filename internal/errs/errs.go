@@ -0,0 +1,188 @@
+// Package errs defines the error taxonomy shared by download, parse and
+// datasets. Callers test categories with errors.Is against the sentinels and
+// extract details with errors.As on the typed errors:
+//
+//	if errors.Is(err, errs.ErrNotFound) { ... }
+//	var pe *errs.ParseError
+//	if errors.As(err, &pe) { log.Printf("%s row %d", pe.URL, pe.Row) }
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinels for errors.Is.
+var (
+	ErrNotFound            = errors.New("not found")              // 404/410: the asset does not exist
+	ErrRateLimited         = errors.New("rate limited")           // 429 or GitHub's rate-limit 403
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")   // 5xx or a transport failure
+	ErrParse               = errors.New("parse failure")          // malformed CSV/Parquet/compression
+	ErrSchemaMismatch      = errors.New("schema mismatch")        // file lacks the columns a model expects
+	ErrSeasonUnavailable   = errors.New("season not available")   // season outside what a dataset publishes
+	ErrNotCached           = errors.New("not cached")             // offline mode and the asset isn't in the cache
+	ErrIntegrity           = errors.New("integrity check failed") // truncated body, bad checksum, or an error page instead of data
+)
+
+// Error annotates a failure with the dataset and URL it came from. Every
+// error returned by the dataset loaders is (or wraps) an *Error.
+type Error struct {
+	Dataset string // dataset key or source base path; may be empty for raw fetches
+	URL     string // asset URL; empty when no URL was resolved yet
+	Err     error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("nflreadgo")
+	if e.Dataset != "" {
+		b.WriteString(" ")
+		b.WriteString(e.Dataset)
+	}
+	if e.URL != "" {
+		b.WriteString(" (")
+		b.WriteString(e.URL)
+		b.WriteString(")")
+	}
+	b.WriteString(": ")
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Wrap annotates err with dataset and url. An *Error is not wrapped again:
+// err is returned as is when its *Error already has both, and a copy with
+// the empty fields filled in otherwise (err itself may be shared, e.g.
+// between the callers of one load, so it is never modified). Wrap(nil)
+// returns nil.
+func Wrap(dataset, url string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return &Error{Dataset: dataset, URL: url, Err: err}
+	}
+	if (e.Dataset != "" || dataset == "") && (e.URL != "" || url == "") {
+		return err
+	}
+	if e != err {
+		return &Error{Dataset: dataset, URL: url, Err: err} // *Error deeper in the chain
+	}
+	c := *e
+	if c.Dataset == "" {
+		c.Dataset = dataset
+	}
+	if c.URL == "" {
+		c.URL = url
+	}
+	return &c
+}
+
+// ParseError reports malformed input at a position in a file.
+type ParseError struct {
+	URL string
+	Row int // 1-based data row (header excluded); 0 when not row-specific
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	var where []string
+	if e.URL != "" {
+		where = append(where, e.URL)
+	}
+	if e.Row > 0 {
+		where = append(where, fmt.Sprintf("row %d", e.Row))
+	}
+	msg := "parse"
+	if len(where) > 0 {
+		msg += " " + strings.Join(where, " ")
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// Is makes every ParseError match ErrParse.
+func (e *ParseError) Is(target error) bool { return target == ErrParse }
+
+// SchemaError reports a file that has none of the columns a model expects
+// (typically the wrong asset, or an upstream rename).
+type SchemaError struct {
+	URL  string
+	Want []string // columns the model decodes
+	Got  []string // columns the file has
+}
+
+func (e *SchemaError) Error() string {
+	s := fmt.Sprintf("schema mismatch: none of %d expected columns (%s) present", len(e.Want), preview(e.Want))
+	if e.URL != "" {
+		s += " in " + e.URL
+	}
+	return s + fmt.Sprintf("; file has %d columns (%s)", len(e.Got), preview(e.Got))
+}
+
+// Is makes every SchemaError match ErrSchemaMismatch.
+func (e *SchemaError) Is(target error) bool { return target == ErrSchemaMismatch }
+
+// preview joins up to the first five names.
+func preview(names []string) string {
+	if len(names) > 5 {
+		return strings.Join(names[:5], ", ") + ", ..."
+	}
+	return strings.Join(names, ", ")
+}
+
+// IntegrityError reports a body that is not the asset it claims to be: cut
+// short of its Content-Length, failing its checksum, or an HTML/JSON error
+// page served in place of data.
+type IntegrityError struct {
+	URL   string
+	Check string // "content-length", "sha256" or "content"
+	Want  string
+	Got   string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("integrity: %s %s: want %s, got %s", e.URL, e.Check, e.Want, e.Got)
+}
+
+// Is makes every IntegrityError match ErrIntegrity.
+func (e *IntegrityError) Is(target error) bool { return target == ErrIntegrity }
+
+// SeasonErrors collects per-season failures from a multi-season load that
+// was asked to return partial results. errors.Is/As see every member error.
+type SeasonErrors struct {
+	Seasons []int   // failed seasons, in request order
+	Errs    []error // Errs[i] is the failure for Seasons[i]
+}
+
+// Add records err for season.
+func (e *SeasonErrors) Add(season int, err error) {
+	e.Seasons = append(e.Seasons, season)
+	e.Errs = append(e.Errs, err)
+}
+
+// For returns the error recorded for season, or nil.
+func (e *SeasonErrors) For(season int) error {
+	for i, s := range e.Seasons {
+		if s == season {
+			return e.Errs[i]
+		}
+	}
+	return nil
+}
+
+func (e *SeasonErrors) Error() string {
+	parts := make([]string, len(e.Seasons))
+	for i, s := range e.Seasons {
+		parts[i] = fmt.Sprintf("%d: %v", s, e.Errs[i])
+	}
+	return fmt.Sprintf("%d season(s) failed: %s", len(e.Seasons), strings.Join(parts, "; "))
+}
+
+func (e *SeasonErrors) Unwrap() []error { return e.Errs }
@@ -0,0 +1,26 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrap_DoesNotModifySharedError(t *testing.T) {
+	shared := &Error{Dataset: "pbp", Err: ErrNotFound}
+	a := Wrap("pbp", "https://a/pbp_2024.csv", shared)
+	b := Wrap("pbp", "https://b/pbp_2024.csv", shared)
+
+	var ea, eb *Error
+	if !errors.As(a, &ea) || !errors.As(b, &eb) || ea.URL != "https://a/pbp_2024.csv" || eb.URL != "https://b/pbp_2024.csv" {
+		t.Fatalf("Wrap = %v, %v; want each caller's URL", a, b)
+	}
+	if shared.URL != "" {
+		t.Fatalf("shared error changed: %v", shared)
+	}
+	if !errors.Is(a, ErrNotFound) {
+		t.Fatalf("%v lost its cause", a)
+	}
+	if full := Wrap("other", "https://c", a); full != a {
+		t.Fatalf("Wrap of a complete *Error = %v, want it unchanged", full)
+	}
+}
@@ -0,0 +1,78 @@
+// Package flight coalesces concurrent calls that would do the same work:
+// while one call for a key is in flight, later callers wait for it and share
+// its result instead of repeating it (the "singleflight" pattern).
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group runs at most one fn per key at a time. The zero value is ready to use.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+type call[V any] struct {
+	done    chan struct{}
+	val     V
+	err     error
+	waiters int
+}
+
+// Do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result with shared = true.
+// Results are not remembered once the call completes.
+//
+// fn runs with the ctx of whichever caller started it. If that ctx is
+// canceled, waiters whose own ctx is still live start over rather than
+// inheriting the cancellation; a waiter whose ctx ends stops waiting.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func(context.Context) (V, error)) (v V, shared bool, err error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[K]*call[V])
+		}
+		if c, ok := g.calls[key]; ok {
+			c.waiters++
+			g.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				var zero V
+				return zero, false, ctx.Err()
+			case <-c.done:
+			}
+			if isCtxErr(c.err) && ctx.Err() == nil {
+				continue // the starter gave up; try again
+			}
+			return c.val, true, c.err
+		}
+		c := &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+		g.mu.Unlock()
+
+		c.val, c.err = fn(ctx)
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+		return c.val, false, c.err
+	}
+}
+
+// Waiters reports how many callers have joined the in-flight call for key.
+func (g *Group[K, V]) Waiters(key K) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c.waiters
+	}
+	return 0
+}
+
+func isCtxErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
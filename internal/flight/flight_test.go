@@ -0,0 +1,80 @@
+package flight
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo_Coalesces(t *testing.T) {
+	var g Group[string, int]
+	var runs atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	shared := make([]bool, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], shared[i], _ = g.Do(context.Background(), "k", func(context.Context) (int, error) {
+				runs.Add(1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+	for g.Waiters("k") < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Fatalf("fn ran %d times, want 1", runs.Load())
+	}
+	nShared := 0
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result[%d] = %d", i, v)
+		}
+		if shared[i] {
+			nShared++
+		}
+	}
+	if nShared != 4 {
+		t.Fatalf("shared = %d, want 4", nShared)
+	}
+}
+
+func TestDo_StarterCanceled(t *testing.T) {
+	var g Group[string, int]
+	started := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go g.Do(ctx, "k", func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	<-started
+
+	done := make(chan int)
+	go func() {
+		v, _, err := g.Do(context.Background(), "k", func(context.Context) (int, error) { return 7, nil })
+		if err != nil {
+			t.Error(err)
+		}
+		done <- v
+	}()
+	for g.Waiters("k") < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if v := <-done; v != 7 {
+		t.Fatalf("waiter got %d, want its own retry (7)", v)
+	}
+}
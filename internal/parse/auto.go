@@ -1,40 +1,155 @@
 package parse
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/csv"
 	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"path/filepath"
 	"strings"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
 )
 
 // Auto parses bytes into []map[string]any by sniffing URL/bytes.
-// CSV is supported; Parquet returns a clear "not implemented" for now.
+// Parquet is detected by extension or the "PAR1" magic and yields typed
+// values; CSV yields trimmed strings. Gzip/zstd input is decompressed first.
 func Auto(b []byte, usedURL string) ([]map[string]any, error) {
+	return AutoWithEncoding(b, usedURL, "")
+}
+
+// AutoWithEncoding is Auto with the response Content-Encoding as an extra
+// decompression hint.
+func AutoWithEncoding(b []byte, usedURL, contentEncoding string) ([]map[string]any, error) {
+	orig := usedURL
+	b, usedURL, err := Decompress(b, usedURL, contentEncoding)
+	if err != nil {
+		return nil, &errs.ParseError{URL: orig, Err: err}
+	}
+	k, err := sniff(peek512(b), usedURL)
+	if err != nil {
+		return nil, &errs.ParseError{URL: orig, Err: err}
+	}
+	if k == kindParquet {
+		return collect(parquetRows(b, orig))
+	}
+	return collect(csvRows(bytes.NewReader(b), orig))
+}
+
+// Stream decodes rows one at a time from r, decompressing gzip/zstd on the
+// fly. CSV is decoded incrementally; Parquet needs random access, so its
+// (decompressed) bytes are buffered but rows are still yielded one by one.
+// Iteration stops after the first error; malformed input yields an
+// *errs.ParseError carrying usedURL and the failing row.
+func Stream(r io.Reader, usedURL, contentEncoding string) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		dr, inner, err := NewDecompressReader(r, usedURL, contentEncoding)
+		if err != nil {
+			yield(nil, &errs.ParseError{URL: usedURL, Err: err})
+			return
+		}
+		defer dr.Close()
+
+		br := bufio.NewReader(dr)
+		head, _ := br.Peek(512)
+		k, err := sniff(head, inner)
+		if err != nil {
+			yield(nil, &errs.ParseError{URL: usedURL, Err: err})
+			return
+		}
+		if k == kindParquet {
+			b, err := io.ReadAll(br)
+			if err != nil {
+				yield(nil, &errs.ParseError{URL: usedURL, Err: err})
+				return
+			}
+			parquetRows(b, usedURL)(yield)
+			return
+		}
+		csvRows(br, usedURL)(yield)
+	}
+}
+
+type kind int
+
+const (
+	kindCSV kind = iota
+	kindParquet
+)
+
+// sniff picks a decoder from the (decompressed) URL extension and leading bytes.
+func sniff(head []byte, usedURL string) (kind, error) {
 	ext := strings.ToLower(filepath.Ext(usedURL))
-	if ext == ".csv" || looksLikeCSV(b) {
-		return parseCSVMaps(bytes.NewReader(b))
+	if ext == ".parquet" || isParquet(head) {
+		return kindParquet, nil
+	}
+	if what := markup(head); what != "" {
+		return 0, fmt.Errorf("got %s, not CSV (an error response?)", what)
 	}
-	if ext == ".parquet" {
-		return nil, errors.New("parquet parsing not implemented yet")
+	if ext == ".csv" || looksLikeCSV(head) {
+		return kindCSV, nil
 	}
 	// Fallback by content-type sniffing
-	mt := http.DetectContentType(peek512(b))
+	mt := http.DetectContentType(head)
 	if strings.Contains(mt, "text/plain") || strings.Contains(mt, "text/csv") {
-		return parseCSVMaps(bytes.NewReader(b))
+		return kindCSV, nil
 	}
-	return nil, errors.New("unknown content type; cannot parse")
+	return 0, errors.New("unknown content type; cannot parse")
 }
 
-func parseCSVMaps(r io.Reader) ([]map[string]any, error) {
-	cr := csv.NewReader(r)
-	cr.FieldsPerRecord = -1
-	hdr, err := cr.Read()
-	if err != nil {
-		return nil, err
+// csvRows yields one normalized row map per CSV record. Errors are
+// *errs.ParseError values naming url and the 1-based data row.
+func csvRows(r io.Reader, url string) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		hdr, err := cr.Read()
+		if err != nil {
+			yield(nil, &errs.ParseError{URL: url, Err: fmt.Errorf("read header: %w", err)})
+			return
+		}
+		norm := normalizeHeader(hdr)
+
+		for n := 1; ; n++ {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(nil, &errs.ParseError{URL: url, Row: n, Err: err})
+				return
+			}
+			m := make(map[string]any, len(norm))
+			for i := range norm {
+				if i < len(rec) {
+					m[norm[i]] = strings.TrimSpace(rec[i])
+				}
+			}
+			if !yield(m, nil) {
+				return
+			}
+		}
 	}
+}
+
+// collect drains a row iterator into a slice, stopping at the first error.
+func collect(seq iter.Seq2[map[string]any, error]) ([]map[string]any, error) {
+	var out []map[string]any
+	for m, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// normalizeHeader lowercases/underscores column names and disambiguates duplicates.
+func normalizeHeader(hdr []string) []string {
 	norm := make([]string, len(hdr))
 	dupCount := map[string]int{}
 	for i, h := range hdr {
@@ -45,25 +160,7 @@ func parseCSVMaps(r io.Reader) ([]map[string]any, error) {
 		}
 		norm[i] = base
 	}
-
-	var out []map[string]any
-	for {
-		rec, err := cr.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		m := make(map[string]any, len(norm))
-		for i := range norm {
-			if i < len(rec) {
-				m[norm[i]] = strings.TrimSpace(rec[i])
-			}
-		}
-		out = append(out, m)
-	}
-	return out, nil
+	return norm
 }
 
 // func normalize(s string) string {
@@ -104,6 +201,21 @@ func looksLikeCSV(b []byte) bool {
 	return strings.Contains(s, ",") && strings.Contains(s, "\n")
 }
 
+// markup names the document type when head starts like HTML or JSON,
+// which looksLikeCSV would otherwise happily accept.
+func markup(head []byte) string {
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+	switch {
+	case len(head) == 0:
+		return ""
+	case head[0] == '<':
+		return "HTML"
+	case head[0] == '{' || head[0] == '[':
+		return "JSON"
+	}
+	return ""
+}
+
 func peek512(b []byte) []byte {
 	if len(b) > 512 {
 		return b[:512]
@@ -0,0 +1,23 @@
+package parse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+func TestStream_RejectsErrorPages(t *testing.T) {
+	for _, body := range []string{
+		"<!DOCTYPE html>\n<html><body>rate limited, try again</body></html>\n",
+		"\xef\xbb\xbf  {\"message\": \"Not Found\", \"documentation_url\": \"x\"}\n",
+	} {
+		for _, err := range Stream(strings.NewReader(body), "https://example.com/x.csv", "") {
+			if !errors.Is(err, errs.ErrParse) {
+				t.Errorf("body %.20q: err = %v, want a parse error", body, err)
+			}
+			break
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/tyler180/nfl-data-go/internal/errs"
 	"github.com/tyler180/nfl-data-go/internal/schema"
 )
 
@@ -25,18 +26,18 @@ func SnapCountsCSV(r io.Reader) ([]schema.SnapCount, error) {
 
 	header, err := cr.Read()
 	if err != nil {
-		return nil, fmt.Errorf("read header: %w", err)
+		return nil, &errs.ParseError{Err: fmt.Errorf("read header: %w", err)}
 	}
 	idx := indexHeader(header)
 
 	var out []schema.SnapCount
-	for {
+	for n := 1; ; n++ {
 		rec, err := cr.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("read row: %w", err)
+			return nil, &errs.ParseError{Row: n, Err: fmt.Errorf("read row: %w", err)}
 		}
 		row := func(key string) string {
 			if i, ok := idx[key]; ok && i >= 0 && i < len(rec) {
@@ -44,37 +45,42 @@ func SnapCountsCSV(r io.Reader) ([]schema.SnapCount, error) {
 			}
 			return ""
 		}
+		out = append(out, snapCountFrom(row))
+	}
+	return out, nil
+}
 
-		season := atoi(row("season"))
-		week := atoi(row("week"))
-		gameID := row("game_id")
-		if gameID == "" {
-			gameID = row("gameid")
-		}
-		playerID := firstNonEmpty(row("player_id"), row("gsis_id"), row("playerid"))
-		team := strings.ToUpper(row("team"))
-
-		offSnaps := atoi(firstNonEmpty(row("offense_snaps"), row("team_snaps")))
-		playerSnaps := atoi(firstNonEmpty(row("player_snaps"), row("snaps")))
-		var snapPct float64
-		if v := row("snap_pct"); v != "" {
-			snapPct = atof(v)
-		} else if offSnaps > 0 && playerSnaps >= 0 {
-			snapPct = 100.0 * float64(playerSnaps) / float64(offSnaps)
-		}
+// snapCountFrom builds a SnapCount from a column accessor keyed by
+// normalized header name (synonyms already applied).
+func snapCountFrom(row func(key string) string) schema.SnapCount {
+	season := atoi(row("season"))
+	week := atoi(row("week"))
+	gameID := row("game_id")
+	if gameID == "" {
+		gameID = row("gameid")
+	}
+	playerID := firstNonEmpty(row("player_id"), row("gsis_id"), row("playerid"))
+	team := strings.ToUpper(row("team"))
 
-		out = append(out, schema.SnapCount{
-			Season:       season,
-			Week:         week,
-			GameID:       gameID,
-			PlayerID:     playerID,
-			Team:         team,
-			OffenseSnaps: offSnaps,
-			PlayerSnaps:  playerSnaps,
-			SnapPct:      snapPct,
-		})
+	offSnaps := atoi(firstNonEmpty(row("offense_snaps"), row("team_snaps")))
+	playerSnaps := atoi(firstNonEmpty(row("player_snaps"), row("snaps")))
+	var snapPct float64
+	if v := row("snap_pct"); v != "" {
+		snapPct = atof(v)
+	} else if offSnaps > 0 && playerSnaps >= 0 {
+		snapPct = 100.0 * float64(playerSnaps) / float64(offSnaps)
+	}
+
+	return schema.SnapCount{
+		Season:       season,
+		Week:         week,
+		GameID:       gameID,
+		PlayerID:     playerID,
+		Team:         team,
+		OffenseSnaps: offSnaps,
+		PlayerSnaps:  playerSnaps,
+		SnapPct:      snapPct,
 	}
-	return out, nil
 }
 
 // ---- helpers ----
@@ -93,22 +99,35 @@ func indexHeader(hdr []string) map[string]int {
 		idx[normalize(h)] = i
 	}
 	// Add synonym keys pointing at the same index if present
-	setSyn := func(alias, base string) {
-		if i, ok := idx[base]; ok {
-			idx[alias] = i
+	for _, syn := range snapSynonyms {
+		if i, ok := idx[syn[1]]; ok {
+			idx[syn[0]] = i
 		}
 	}
-	setSyn("player_id", "gsis_id")
-	setSyn("gsis_id", "player_id")
-	setSyn("offense_snaps", "team_snaps")
-	setSyn("team_snaps", "offense_snaps")
-	setSyn("player_snaps", "snaps")
-	setSyn("snap_pct", "snap_percentage")
-	setSyn("gameid", "game_id")
-	setSyn("playerid", "player_id")
 	return idx
 }
 
+// snapSynonyms lists {alias, base} header pairs accepted for snap counts.
+var snapSynonyms = [][2]string{
+	{"player_id", "gsis_id"},
+	{"gsis_id", "player_id"},
+	{"offense_snaps", "team_snaps"},
+	{"team_snaps", "offense_snaps"},
+	{"player_snaps", "snaps"},
+	{"snap_pct", "snap_percentage"},
+	{"gameid", "game_id"},
+	{"playerid", "player_id"},
+}
+
+// addSynonyms is the row-map equivalent of the synonym pass in indexHeader.
+func addSynonyms(m map[string]any) {
+	for _, syn := range snapSynonyms {
+		if v, ok := m[syn[1]]; ok {
+			m[syn[0]] = v
+		}
+	}
+}
+
 func firstNonEmpty(vs ...string) string {
 	for _, v := range vs {
 		if v != "" {
@@ -127,3 +146,23 @@ func atof(s string) float64 {
 	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
 	return f
 }
+
+// toString renders a typed row value (as produced by the Parquet decoder).
+func toString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case []byte:
+		return strings.TrimSpace(string(t))
+	case int:
+		return strconv.Itoa(t)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,118 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression identifies a transport/file compression wrapper.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZstd
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// DetectCompression sniffs compression from magic bytes first, then the
+// Content-Encoding value, then a .gz/.zst suffix on usedURL.
+func DetectCompression(head []byte, usedURL, contentEncoding string) Compression {
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		return CompressionGzip
+	case bytes.HasPrefix(head, zstdMagic):
+		return CompressionZstd
+	}
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "gzip", "x-gzip":
+		return CompressionGzip
+	case "zstd":
+		return CompressionZstd
+	}
+	u := strings.ToLower(usedURL)
+	switch {
+	case strings.HasSuffix(u, ".gz"):
+		return CompressionGzip
+	case strings.HasSuffix(u, ".zst"), strings.HasSuffix(u, ".zstd"):
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// StripCompressionExt removes a trailing .gz/.zst/.zstd so extension
+// sniffing sees the inner format (e.g., "x.csv.gz" → "x.csv").
+func StripCompressionExt(usedURL string) string {
+	u := strings.ToLower(usedURL)
+	for _, ext := range []string{".gz", ".zst", ".zstd"} {
+		if strings.HasSuffix(u, ext) {
+			return usedURL[:len(usedURL)-len(ext)]
+		}
+	}
+	return usedURL
+}
+
+// NewDecompressReader wraps r with a gzip or zstd decoder when the stream is
+// compressed, and returns usedURL with any compression suffix removed.
+// Uncompressed streams are passed through (buffered) unchanged.
+func NewDecompressReader(r io.Reader, usedURL, contentEncoding string) (io.ReadCloser, string, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4) // short reads are fine; DetectCompression handles them
+	inner := StripCompressionExt(usedURL)
+
+	switch DetectCompression(head, usedURL, contentEncoding) {
+	case CompressionGzip:
+		if !bytes.HasPrefix(head, gzipMagic) {
+			// Labeled gzip but not actually compressed (e.g., the transport
+			// already decoded it); pass through.
+			return io.NopCloser(br), inner, nil
+		}
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, "", fmt.Errorf("gzip: %w", err)
+		}
+		return zr, inner, nil
+	case CompressionZstd:
+		if !bytes.HasPrefix(head, zstdMagic) {
+			return io.NopCloser(br), inner, nil
+		}
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, "", fmt.Errorf("zstd: %w", err)
+		}
+		return zr.IOReadCloser(), inner, nil
+	default:
+		return io.NopCloser(br), usedURL, nil
+	}
+}
+
+// Decompress is the []byte form of NewDecompressReader. Input without a
+// gzip/zstd magic prefix is returned as-is without copying.
+func Decompress(b []byte, usedURL, contentEncoding string) ([]byte, string, error) {
+	if !bytes.HasPrefix(b, gzipMagic) && !bytes.HasPrefix(b, zstdMagic) {
+		if DetectCompression(nil, usedURL, contentEncoding) == CompressionNone {
+			return b, usedURL, nil
+		}
+		return b, StripCompressionExt(usedURL), nil
+	}
+	rc, inner, err := NewDecompressReader(bytes.NewReader(b), usedURL, contentEncoding)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rc.Close()
+	out, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, "", fmt.Errorf("decompress: %w", err)
+	}
+	return out, inner, nil
+}
@@ -0,0 +1,57 @@
+package parse
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const sampleCSV = "Season,Team\n2024,KC\n2024,BUF\n"
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAuto_GzipCSV(t *testing.T) {
+	rows, err := Auto(gzipBytes(t, sampleCSV), "https://example.com/injuries_2024.csv.gz")
+	if err != nil {
+		t.Fatalf("Auto: %v", err)
+	}
+	if len(rows) != 2 || rows[1]["team"] != "BUF" {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestAuto_ZstdByMagic(t *testing.T) {
+	enc, _ := zstd.NewWriter(nil)
+	b := enc.EncodeAll([]byte(sampleCSV), nil)
+	enc.Close()
+
+	rows, err := Auto(b, "https://example.com/no_extension")
+	if err != nil {
+		t.Fatalf("Auto: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["season"] != "2024" {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestDecompress_LabeledButPlain(t *testing.T) {
+	// net/http may already have decoded the body; labels alone must not fail.
+	b, inner, err := Decompress([]byte(sampleCSV), "https://example.com/x.csv.gz", "gzip")
+	if err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+	if string(b) != sampleCSV || inner != "https://example.com/x.csv" {
+		t.Fatalf("got %q, %q", b, inner)
+	}
+}
@@ -1,14 +1,208 @@
 package parse
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"time"
 
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
+
+	"github.com/tyler180/nfl-data-go/internal/errs"
 	"github.com/tyler180/nfl-data-go/internal/schema"
 )
 
+// parquetMagic is the 4-byte marker at the start (and end) of every Parquet file.
+var parquetMagic = []byte("PAR1")
+
+// isParquet reports whether b starts with the Parquet magic bytes.
+func isParquet(b []byte) bool { return bytes.HasPrefix(b, parquetMagic) }
+
 // SnapCountsParquet parses a Parquet stream into []nflreadgo.SnapCount.
-// TODO: implement with a parquet reader (e.g., github.com/apache/arrow/go/arrow/parquet)
-func SnapCountsParquet(_ io.Reader) ([]schema.SnapCount, error) {
-	return nil, fmt.Errorf("parquet parsing not implemented yet (use CSV)")
+// Column names and synonyms follow SnapCountsCSV.
+func SnapCountsParquet(r io.Reader) ([]schema.SnapCount, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read parquet: %w", err)
+	}
+	rows, err := collect(parquetRows(b, ""))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]schema.SnapCount, 0, len(rows))
+	for _, m := range rows {
+		addSynonyms(m)
+		out = append(out, snapCountFrom(func(key string) string {
+			if v, ok := m[key]; ok && v != nil {
+				return toString(v)
+			}
+			return ""
+		}))
+	}
+	return out, nil
+}
+
+// parquetColumn describes how a leaf column maps into a row map.
+type parquetColumn struct {
+	name     string // normalized top-level column name
+	repeated bool   // list columns collect into []any
+	decode   func(parquet.Value) any
+}
+
+// parquetRows decodes a Parquet file into normalized row maps, one at a time.
+// Values are typed: INT32/INT64 → int, FLOAT/DOUBLE → float64, BOOLEAN → bool,
+// strings/binary → string. DATE columns become "YYYY-MM-DD" strings and
+// TIMESTAMP/INT96 columns become RFC 3339 strings so the dataset FromMap
+// helpers can treat them like their CSV counterparts. Nulls are omitted.
+// Errors are *errs.ParseError values naming url and the failing row.
+func parquetRows(b []byte, url string) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			yield(nil, &errs.ParseError{URL: url, Err: fmt.Errorf("open parquet: %w", err)})
+			return
+		}
+		cols, err := parquetColumns(f.Schema())
+		if err != nil {
+			yield(nil, &errs.ParseError{URL: url, Err: err})
+			return
+		}
+
+		r := parquet.NewReader(f)
+		defer r.Close()
+
+		buf := make([]parquet.Row, 256)
+		read := 0
+		for {
+			n, err := r.ReadRows(buf)
+			for _, row := range buf[:n] {
+				if !yield(parquetRowMap(row, cols), nil) {
+					return
+				}
+			}
+			read += n
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, &errs.ParseError{URL: url, Row: read + 1, Err: fmt.Errorf("read parquet rows: %w", err)})
+				return
+			}
+		}
+	}
+}
+
+func parquetColumns(s *parquet.Schema) ([]parquetColumn, error) {
+	paths := s.Columns()
+	top := make([]string, len(paths))
+	for i, p := range paths {
+		top[i] = p[0]
+	}
+	names := normalizeHeader(top)
+
+	cols := make([]parquetColumn, len(paths))
+	for i, p := range paths {
+		leaf, ok := s.Lookup(p...)
+		if !ok {
+			return nil, fmt.Errorf("parquet: column %v missing from schema", p)
+		}
+		cols[leaf.ColumnIndex] = parquetColumn{
+			name:     names[i],
+			repeated: leaf.MaxRepetitionLevel > 0,
+			decode:   parquetDecoder(leaf.Node.Type()),
+		}
+	}
+	return cols, nil
+}
+
+func parquetRowMap(row parquet.Row, cols []parquetColumn) map[string]any {
+	m := make(map[string]any, len(cols))
+	for _, v := range row {
+		ci := v.Column()
+		if ci < 0 || ci >= len(cols) || v.IsNull() {
+			continue
+		}
+		c := cols[ci]
+		if c.repeated {
+			list, _ := m[c.name].([]any)
+			m[c.name] = append(list, c.decode(v))
+			continue
+		}
+		m[c.name] = c.decode(v)
+	}
+	return m
+}
+
+func parquetDecoder(t parquet.Type) func(parquet.Value) any {
+	lt := t.LogicalType()
+	ct := t.ConvertedType()
+
+	switch t.Kind() {
+	case parquet.Boolean:
+		return func(v parquet.Value) any { return v.Boolean() }
+
+	case parquet.Int32:
+		if (lt != nil && lt.Date != nil) || (ct != nil && *ct == deprecated.Date) {
+			return func(v parquet.Value) any {
+				return time.Unix(int64(v.Int32())*86400, 0).UTC().Format("2006-01-02")
+			}
+		}
+		return func(v parquet.Value) any { return int(v.Int32()) }
+
+	case parquet.Int64:
+		if unit, ok := timestampUnit(lt, ct); ok {
+			return func(v parquet.Value) any {
+				return time.Unix(0, v.Int64()*int64(unit)).UTC().Format(time.RFC3339Nano)
+			}
+		}
+		return func(v parquet.Value) any { return int(v.Int64()) }
+
+	case parquet.Int96:
+		return func(v parquet.Value) any { return int96Time(v.Int96()).Format(time.RFC3339Nano) }
+
+	case parquet.Float:
+		return func(v parquet.Value) any { return float64(v.Float()) }
+
+	case parquet.Double:
+		return func(v parquet.Value) any { return v.Double() }
+
+	default: // ByteArray, FixedLenByteArray
+		return func(v parquet.Value) any { return string(v.ByteArray()) }
+	}
+}
+
+// timestampUnit returns the duration of one tick for TIMESTAMP columns.
+func timestampUnit(lt *format.LogicalType, ct *deprecated.ConvertedType) (time.Duration, bool) {
+	if lt != nil && lt.Timestamp != nil {
+		switch {
+		case lt.Timestamp.Unit.Millis != nil:
+			return time.Millisecond, true
+		case lt.Timestamp.Unit.Micros != nil:
+			return time.Microsecond, true
+		case lt.Timestamp.Unit.Nanos != nil:
+			return time.Nanosecond, true
+		}
+	}
+	if ct != nil {
+		switch *ct {
+		case deprecated.TimestampMillis:
+			return time.Millisecond, true
+		case deprecated.TimestampMicros:
+			return time.Microsecond, true
+		}
+	}
+	return 0, false
+}
+
+// int96Time converts a legacy Impala/Spark INT96 timestamp
+// (nanoseconds-of-day + Julian day number) to UTC.
+func int96Time(i deprecated.Int96) time.Time {
+	const julianUnixEpoch = 2440588
+	nanos := int64(i[1])<<32 | int64(i[0])
+	days := int64(i[2]) - julianUnixEpoch
+	return time.Unix(days*86400, nanos).UTC()
 }
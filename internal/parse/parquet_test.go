@@ -0,0 +1,90 @@
+package parse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type parquetFixture struct {
+	Season   int32    `parquet:"season"`
+	Team     string   `parquet:"Team"`
+	Snaps    int64    `parquet:"offense_snaps"`
+	SnapPct  float64  `parquet:"snap_pct"`
+	Starter  bool     `parquet:"starter"`
+	PlayerID *string  `parquet:"player_id,optional"`
+	Tags     []string `parquet:"tags,list"`
+}
+
+func writeParquet(t *testing.T, rows []parquetFixture) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[parquetFixture](&buf)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAuto_Parquet(t *testing.T) {
+	id := "00-0012345"
+	b := writeParquet(t, []parquetFixture{
+		{Season: 2024, Team: "KC", Snaps: 65, SnapPct: 0.92, Starter: true, PlayerID: &id, Tags: []string{"a", "b"}},
+		{Season: 2024, Team: "BUF", Snaps: 60},
+	})
+
+	// No extension: must be sniffed by magic bytes, not mistaken for CSV.
+	rows, err := Auto(b, "https://example.com/snap_counts_2024")
+	if err != nil {
+		t.Fatalf("Auto: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	r := rows[0]
+	if got, ok := r["season"].(int); !ok || got != 2024 {
+		t.Errorf("season = %#v, want int 2024", r["season"])
+	}
+	if got, ok := r["team"].(string); !ok || got != "KC" {
+		t.Errorf("team = %#v, want normalized key with string KC", r["team"])
+	}
+	if got, ok := r["offense_snaps"].(int); !ok || got != 65 {
+		t.Errorf("offense_snaps = %#v, want int 65", r["offense_snaps"])
+	}
+	if got, ok := r["snap_pct"].(float64); !ok || got != 0.92 {
+		t.Errorf("snap_pct = %#v, want float64 0.92", r["snap_pct"])
+	}
+	if got, ok := r["starter"].(bool); !ok || !got {
+		t.Errorf("starter = %#v, want true", r["starter"])
+	}
+	if got := r["player_id"]; got != id {
+		t.Errorf("player_id = %#v, want %q", got, id)
+	}
+	if got, ok := r["tags"].([]any); !ok || len(got) != 2 || got[1] != "b" {
+		t.Errorf("tags = %#v, want [a b]", r["tags"])
+	}
+	if _, ok := rows[1]["player_id"]; ok {
+		t.Errorf("null player_id should be omitted, got %#v", rows[1]["player_id"])
+	}
+}
+
+func TestSnapCountsParquet(t *testing.T) {
+	id := "00-0012345"
+	b := writeParquet(t, []parquetFixture{{Season: 2023, Team: "kc", Snaps: 50, PlayerID: &id}})
+
+	out, err := SnapCountsParquet(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("SnapCountsParquet: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("rows = %d, want 1", len(out))
+	}
+	sc := out[0]
+	if sc.Season != 2023 || sc.Team != "KC" || sc.OffenseSnaps != 50 || sc.PlayerID != id {
+		t.Fatalf("unexpected row: %+v", sc)
+	}
+}
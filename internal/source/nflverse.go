@@ -6,24 +6,25 @@ import (
 	"sort"
 )
 
-const defaultSnapPerSeasonPattern = "https://github.com/nflverse/nflverse-data/releases/download/snap_counts/snap_counts_%d.csv"
-
-// NFLVerseSnapCountURLs returns URLs for the given seasons.
-// If NFLREADGO_SNAP_URL is set, it returns exactly that single URL.
-func NFLVerseSnapCountURLs(seasons []int) []string {
+// SnapCountOverrideURLs returns the snap count URLs set through the
+// environment for seasons: NFLREADGO_SNAP_URL, one file covering every
+// season, or else NFLREADGO_SNAP_PATTERN, a per-season fmt pattern such as
+// "https://mirror.example/snap_counts_%d.parquet". ok is false when neither
+// is set; snap counts are then resolved like every other dataset.
+func SnapCountOverrideURLs(seasons []int) (urls []string, ok bool) {
 	if u := os.Getenv("NFLREADGO_SNAP_URL"); u != "" {
-		return []string{u}
+		return []string{u}, true
 	}
 	pattern := os.Getenv("NFLREADGO_SNAP_PATTERN")
 	if pattern == "" {
-		pattern = defaultSnapPerSeasonPattern
+		return nil, false
 	}
 	ss := append([]int(nil), seasons...)
 	sort.Ints(ss)
 
-	urls := make([]string, 0, len(ss))
+	urls = make([]string, 0, len(ss))
 	for _, yr := range ss {
 		urls = append(urls, fmt.Sprintf(pattern, yr))
 	}
-	return urls
+	return urls, true
 }
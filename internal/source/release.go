@@ -0,0 +1,124 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Resolver turns a repo-relative asset path (including its extension, e.g.
+// "injuries/injuries_2024.parquet") into a download URL. Loaders resolve
+// every URL through a Resolver so raw-vs-release layout lives in one place.
+type Resolver interface {
+	URL(repo, path string) string
+}
+
+// RawResolver serves every path from raw.githubusercontent.com.
+type RawResolver struct{}
+
+func (RawResolver) URL(repo, path string) string { return RawGitHubURL(repo, path) }
+
+// ReleaseResolver serves Repos from GitHub release assets (see
+// ReleaseAssetFor) and everything else from raw.githubusercontent.com.
+type ReleaseResolver struct {
+	Repos []string // "owner/repo"; a bare "repo" defaults to owner nflverse
+}
+
+func (r ReleaseResolver) URL(repo, path string) string {
+	if slices.Contains(r.Repos, NormalizeRepo(repo)) {
+		return ReleaseAssetFor(repo, path).URL()
+	}
+	return RawGitHubURL(repo, path)
+}
+
+// DefaultResolver publishes nflverse-data via releases (its master branch
+// does not carry the data files) and other repos via raw URLs.
+var DefaultResolver Resolver = ReleaseResolver{Repos: []string{"nflverse/nflverse-data"}}
+
+// ReleaseAsset names one file attached to a GitHub release.
+type ReleaseAsset struct {
+	Repo      string    // "owner/repo"
+	Tag       string    // release tag, e.g. "injuries"
+	Name      string    // asset file name, e.g. "injuries_2024.parquet"
+	Size      int64     // from the manifest; 0 when unknown
+	UpdatedAt time.Time // from the manifest; zero when unknown
+	Digest    string    // from the manifest, e.g. "sha256:<hex>"; empty when unknown
+}
+
+// URL returns the asset's browser download URL.
+func (a ReleaseAsset) URL() string { return ReleaseURL(a.Repo, a.Tag, a.Name) }
+
+// ReleaseURL builds https://github.com/<owner>/<repo>/releases/download/<tag>/<name>.
+func ReleaseURL(repo, tag, name string) string {
+	return fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", NormalizeRepo(repo), tag, name)
+}
+
+// ParseReleaseURL is the inverse of ReleaseURL: it splits a release
+// download URL into its repo, tag and asset name.
+func ParseReleaseURL(u string) (ReleaseAsset, bool) {
+	rest, ok := strings.CutPrefix(u, "https://github.com/")
+	if !ok {
+		return ReleaseAsset{}, false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 6 || parts[2] != "releases" || parts[3] != "download" {
+		return ReleaseAsset{}, false
+	}
+	return ReleaseAsset{Repo: parts[0] + "/" + parts[1], Tag: parts[4], Name: parts[5]}, true
+}
+
+// ReleaseAssetFor maps a repo path to its release asset. nflverse-data
+// publishes "<dir>/<file>" as asset <file> under release tag <dir>; a leading
+// "data/" (raw-layout paths) is ignored. Paths without a directory use the
+// file's stem as the tag.
+func ReleaseAssetFor(repo, path string) ReleaseAsset {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "data/")
+	tag, name := path, path
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		tag, name = path[:i], path[i+1:]
+		if j := strings.IndexByte(tag, '/'); j >= 0 {
+			tag = tag[:j]
+		}
+	} else if j := strings.IndexByte(name, '.'); j >= 0 {
+		tag = name[:j]
+	}
+	return ReleaseAsset{Repo: NormalizeRepo(repo), Tag: tag, Name: name}
+}
+
+// ReleaseManifestURL is the GitHub API endpoint describing a release and its
+// assets.
+func ReleaseManifestURL(repo, tag string) string {
+	return fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", NormalizeRepo(repo), tag)
+}
+
+// ParseReleaseManifest decodes a GitHub release JSON document (as served by
+// ReleaseManifestURL) into its assets, in manifest order.
+func ParseReleaseManifest(repo, tag string, r io.Reader) ([]ReleaseAsset, error) {
+	var doc struct {
+		Assets []struct {
+			Name      string    `json:"name"`
+			Size      int64     `json:"size"`
+			UpdatedAt time.Time `json:"updated_at"`
+			Digest    string    `json:"digest"`
+		} `json:"assets"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("release manifest %s@%s: %w", repo, tag, err)
+	}
+	out := make([]ReleaseAsset, 0, len(doc.Assets))
+	for _, a := range doc.Assets {
+		out = append(out, ReleaseAsset{Repo: NormalizeRepo(repo), Tag: tag, Name: a.Name, Size: a.Size, UpdatedAt: a.UpdatedAt, Digest: a.Digest})
+	}
+	return out, nil
+}
+
+// NormalizeRepo returns repo as "owner/repo" (owner defaults to "nflverse").
+func NormalizeRepo(repo string) string {
+	if indexByte(repo, '/') >= 0 {
+		return repo
+	}
+	return "nflverse/" + repo
+}
@@ -0,0 +1,44 @@
+package source
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReleaseAssetFor(t *testing.T) {
+	cases := []struct{ repo, path, tag, name string }{
+		{"nflverse-data", "injuries/injuries_2024.parquet", "injuries", "injuries_2024.parquet"},
+		{"nflverse/nflverse-data", "data/depth_charts/depth_charts.csv", "depth_charts", "depth_charts.csv"},
+		{"nflverse-data", "weekly_rosters/roster_weekly_2023.csv.gz", "weekly_rosters", "roster_weekly_2023.csv.gz"},
+		{"nflverse-data", "players.parquet", "players", "players.parquet"},
+	}
+	for _, c := range cases {
+		a := ReleaseAssetFor(c.repo, c.path)
+		if a.Repo != "nflverse/nflverse-data" || a.Tag != c.tag || a.Name != c.name {
+			t.Errorf("ReleaseAssetFor(%q) = %+v, want tag %q name %q", c.path, a, c.tag, c.name)
+		}
+	}
+	want := "https://github.com/nflverse/nflverse-data/releases/download/injuries/injuries_2024.parquet"
+	if got := DefaultResolver.URL("nflverse-data", "injuries/injuries_2024.parquet"); got != want {
+		t.Errorf("DefaultResolver release URL = %q", got)
+	}
+	if a, ok := ParseReleaseURL(want); !ok || a.URL() != want || a.Tag != "injuries" {
+		t.Errorf("ParseReleaseURL(%q) = %+v, %v", want, a, ok)
+	}
+	if got := DefaultResolver.URL("nflverse/nfldata", "data/games.csv"); !strings.HasPrefix(got, "https://raw.githubusercontent.com/nflverse/nfldata/master/") {
+		t.Errorf("DefaultResolver raw URL = %q", got)
+	}
+}
+
+func TestParseReleaseManifest(t *testing.T) {
+	doc := `{"tag_name":"injuries","assets":[
+		{"name":"injuries_2023.parquet","size":123,"updated_at":"2024-01-02T03:04:05Z","digest":"sha256:abc"},
+		{"name":"injuries_2024.csv","size":456,"updated_at":"2024-09-01T00:00:00Z"}]}`
+	got, err := ParseReleaseManifest("nflverse-data", "injuries", strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Name != "injuries_2024.csv" || got[0].Size != 123 || got[0].UpdatedAt.Year() != 2024 || got[0].Digest != "sha256:abc" {
+		t.Fatalf("assets = %+v", got)
+	}
+}
@@ -2,12 +2,11 @@ package nflreadgo
 
 import (
 	"context"
-	"fmt"
 	"sort"
-	"time"
 
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/snapcounts"
 	"github.com/tyler180/nfl-data-go/internal/download"
-	"github.com/tyler180/nfl-data-go/internal/parse"
 	"github.com/tyler180/nfl-data-go/internal/schema"
 	"github.com/tyler180/nfl-data-go/internal/source"
 	// "github.com/tyler180/nfl-data-go/internal/source"
@@ -82,47 +81,137 @@ type SnapCount struct {
 	SnapPct      float64 // 0..100
 }
 
+// LoadSnapCounts loads per-game snap counts for the seasons in sel. Files
+// are resolved and format-negotiated like every other dataset unless
+// NFLREADGO_SNAP_URL or NFLREADGO_SNAP_PATTERN points elsewhere (see
+// source.SnapCountOverrideURLs).
 func LoadSnapCounts(ctx context.Context, sel any, opts ...Option) ([]schema.SnapCount, error) {
+	if _, ok := source.SnapCountOverrideURLs(nil); ok {
+		return loadSnapCountOverrides(ctx, sel, opts)
+	}
+	return loadSeasons(ctx, datasets.SnapCounts, sel, opts, func(ctx context.Context, yr int) ([]schema.SnapCount, error) {
+		rows, err := snapcounts.LoadSeason(ctx, yr)
+		return snapCountsOf(rows), err
+	}, snapCountAt)
+}
+
+// loadSnapCountOverrides is LoadSnapCounts reading the environment's
+// override URLs: one per season, or a single file covering them all.
+func loadSnapCountOverrides(ctx context.Context, sel any, opts []Option) ([]schema.SnapCount, error) {
 	cfg := buildConfig(opts)
-	dl := download.New(
-		download.WithUserAgent(cfg.UserAgent),
-		download.WithHTTPClient(cfg.HTTPClient()),
-		download.WithCache(cfg.CacheBackend()), // fs or memory or nil
-	)
-
-	selInt := expandSeasons(sel)
-	if len(selInt) == 0 {
+	ctx = datasets.WithConfig(ctx, &cfg)
+
+	cur := seasonAt(cfg.Now())
+	seasons, err := resolveSeasons(datasets.SnapCounts, sel, cur)
+	if err != nil {
+		return nil, err
+	}
+	if len(seasons) == 0 {
 		return nil, nil // nothing to load
 	}
+	urls, _ := source.SnapCountOverrideURLs(seasons)
 
-	urls := source.NFLVerseSnapCountURLs(selInt) // returns []string
-	if len(urls) > 0 {
-		for _, url := range urls {
-			fmt.Printf("url: %s", url)
-		}
-	} else {
-		return nil, fmt.Errorf("no URLs found for selection: %+v", sel)
-	}
 	var out []schema.SnapCount
-
-	for _, u := range urls {
-		rc, _, err := dl.Fetch(ctx, u)
+	if len(urls) != len(seasons) {
+		// A single override URL (NFLREADGO_SNAP_URL) covers every season.
+		rows, err := snapcounts.LoadURL(ctx, 0, urls[0])
 		if err != nil {
 			return nil, err
 		}
-		rows, err := parse.SnapCountsCSV(rc)
-		rc.Close()
-		if err != nil {
+		out = snapCountsOf(rows)
+	} else {
+		byYear := make(map[int]string, len(urls))
+		for i, yr := range seasons {
+			byYear[yr] = urls[i]
+		}
+		out, err = datasets.LoadSeasonsConcurrently(ctx, seasons, multiOptions(cfg), func(ctx context.Context, yr int) ([]schema.SnapCount, error) {
+			rows, err := snapcounts.LoadURL(ctx, yr, byYear[yr])
+			return snapCountsOf(rows), err
+		})
+		if err != nil && !cfg.PartialResults {
 			return nil, err
 		}
-		out = append(out, rows...)
 	}
-	return filterBySelection(out, sel), nil
+	return filterBySelection(out, sel, cur, snapCountAt), err
+}
+
+// snapCountsOf narrows dataset rows to the public SnapCount shape.
+func snapCountsOf(rows []snapcounts.SnapCount) []schema.SnapCount {
+	if rows == nil {
+		return nil
+	}
+	out := make([]schema.SnapCount, len(rows))
+	for i, r := range rows {
+		out[i] = schema.SnapCount{
+			Season:       r.Season,
+			Week:         r.Week,
+			GameID:       r.GameID,
+			PlayerID:     r.PlayerID,
+			Team:         r.Team,
+			OffenseSnaps: r.OffenseSnaps,
+			PlayerSnaps:  r.PlayerSnaps,
+			SnapPct:      r.SnapPct,
+		}
+	}
+	return out
+}
+
+func snapCountAt(r schema.SnapCount) (int, int) { return r.Season, r.Week }
+
+// newDownloader builds a download.Client wired to cfg.
+func newDownloader(cfg Config) *download.Client {
+	return cfg.NewClient()
+}
+
+// loadSeasons is the common shape of the season-scoped public loaders: wire
+// cfg into ctx, expand sel to key's seasons, load them with up to cfg.Workers
+// in parallel (rows stay in season order), and then apply week-level
+// filtering via at. With cfg.PartialResults, rows from the seasons that
+// loaded are returned alongside an *errs.SeasonErrors.
+func loadSeasons[T any](ctx context.Context, key datasets.Key, sel any, opts []Option, load func(context.Context, int) ([]T, error), at func(T) (season, week int)) ([]T, error) {
+	cfg := buildConfig(opts)
+	ctx = datasets.WithConfig(ctx, &cfg)
+
+	cur := seasonAt(cfg.Now())
+	seasons, err := resolveSeasons(key, sel, cur)
+	if err != nil {
+		return nil, err
+	}
+	if len(seasons) == 0 {
+		return nil, nil // nothing to load
+	}
+
+	out, err := datasets.LoadSeasonsConcurrently(ctx, seasons, multiOptions(cfg), load)
+	if err != nil && !cfg.PartialResults {
+		return nil, err
+	}
+	return filterBySelection(out, sel, cur, at), err
+}
+
+// multiOptions maps cfg's parallelism settings onto the fan-out loader.
+func multiOptions(cfg Config) datasets.MultiOptions {
+	return datasets.MultiOptions{Workers: cfg.Workers, Partial: cfg.PartialResults}
 }
 
 // ---- selection expansion ----
 
-func expandSeasons(sel any) []int {
+// resolveSeasons expands sel against key's published seasons and rejects
+// seasons the dataset doesn't have (datasets.ErrSeasonUnavailable).
+func resolveSeasons(key datasets.Key, sel any, cur int) ([]int, error) {
+	all, err := datasets.AvailableSeasons(key, cur)
+	if err != nil {
+		return nil, err
+	}
+	seasons := expandSeasons(sel, cur, all)
+	if err := datasets.CheckSeasons(key, seasons, cur); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// expandSeasons lists the seasons sel covers; cur is the current season and
+// all the dataset's available seasons (what bool(true) expands to).
+func expandSeasons(sel any, cur int, all []int) []int {
 	switch v := sel.(type) {
 	case Seasons:
 		cp := append([]int(nil), v...)
@@ -140,9 +229,14 @@ func expandSeasons(sel any) []int {
 		sort.Ints(out)
 		return out
 	case bool:
-		// true == "all" (follow nflreadpy pattern). For now, return a sane range.
-		// You can refine this by reading available tags or index files.
-		return []int{2016, 2017, 2018, 2019, 2020, 2021, 2022, 2023, 2024, GetCurrentSeason()}
+		// true == "all" (follow nflreadpy pattern); false selects nothing.
+		if !v {
+			return nil
+		}
+		return append([]int(nil), all...)
+	case Weeks:
+		// Bare weeks apply to the current season (see filterBySelection).
+		return []int{cur}
 	case int:
 		return []int{v}
 	case []int:
@@ -173,7 +267,3 @@ func uniqueSort(xs *[]int) {
 // 	return nil, nil
 // }
 // func LoadPlayers(ctx context.Context, opts ...Option) ([]schema.Player, error) { /* ... */ return nil, nil }
-
-func GetCurrentSeason() int { /* mirror nflreadpy */ return time.Now().Year() /* refine by month */ }
-
-// func GetCurrentWeek(ctx context.Context) (int, error) { /* derive from schedules */ return 1, nil }
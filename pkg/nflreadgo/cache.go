@@ -0,0 +1,146 @@
+package nflreadgo
+
+import (
+	"io/fs"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/config"
+	"github.com/tyler180/nfl-data-go/internal/download"
+)
+
+// Cache is the storage contract behind every loader's downloads; implement
+// it to plug in your own backend and pass it with WithCacheBackend.
+// Implementations must be safe for concurrent use. They may also implement
+// CacheIndex (for CacheEntries/ClearCache/PruneCache), a
+// Stats() CacheStats method (for GetCacheStats), CachePartials (to
+// resume interrupted downloads; the filesystem cache does) and a
+// Volatile() bool method reporting true when entries don't outlive the
+// process (Prefetch refuses such caches).
+type Cache = download.Cache
+
+// CacheMetadata is what a Cache stores alongside each body: validators for
+// conditional GETs, size, encoding, save time and the dataset/season label.
+type CacheMetadata = download.Metadata
+
+// CacheIndex is the optional listing/removal half of a Cache.
+type CacheIndex = download.Index
+
+// CachePartials is the optional half of a Cache that keeps interrupted
+// downloads so they resume with HTTP Range requests.
+type CachePartials = download.Partials
+
+// CachePartialWriter receives one download for a CachePartials cache.
+type CachePartialWriter = download.PartialWriter
+
+// CacheOption configures NewMemCache and NewFSCache.
+type CacheOption = download.CacheOption
+
+// S3Options configures NewS3Cache.
+type S3Options = download.S3Options
+
+// WithCacheBackend makes loaders use c instead of the cache described by
+// WithCache / NFLREADGO_CACHE.
+//
+//	remote := nflreadgo.NewS3Cache(nflreadgo.S3Options{Endpoint: ..., Bucket: "nfl", ...})
+//	tiers := nflreadgo.NewLayeredCache(
+//		nflreadgo.NewMemCache(time.Hour, nflreadgo.WithMaxBytes(1<<30)),
+//		nflreadgo.NewFSCache(dir, 24*time.Hour),
+//		remote,
+//	)
+//	rows, err := nflreadgo.LoadPBP(ctx, 2024, nflreadgo.WithCacheBackend(tiers))
+func WithCacheBackend(c Cache) Option { return config.WithCacheBackend(c) }
+
+// WithMaxBytes caps a cache built by NewMemCache or NewFSCache, evicting
+// least recently used entries past n bytes.
+func WithMaxBytes(n int64) CacheOption { return download.WithMaxBytes(n) }
+
+// NewMemCache returns an in-process cache whose entries expire after ttl
+// (0 = never).
+func NewMemCache(ttl time.Duration, opts ...CacheOption) Cache {
+	return download.NewMemCache(ttl, opts...)
+}
+
+// NewFSCache returns a cache in dir; it may be a directory shared between
+// processes or machines, since entries are written atomically.
+func NewFSCache(dir string, ttl time.Duration, opts ...CacheOption) Cache {
+	return download.NewFSCache(dir, ttl, opts...)
+}
+
+// NewS3Cache returns a cache in an S3-compatible bucket (AWS S3, MinIO, R2,
+// ...) for sharing downloads across machines.
+func NewS3Cache(o S3Options) Cache { return download.NewS3Cache(o) }
+
+// NewLayeredCache stacks caches fastest first: reads fall through the tiers
+// and are copied upward, writes go to every tier.
+func NewLayeredCache(layers ...Cache) Cache { return download.NewLayeredCache(layers...) }
+
+// NewFixtureCache serves fixed files from fsys for deterministic tests: the
+// file for a URL is its path without the scheme (e.g.
+// "github.com/nflverse/nflverse-data/releases/download/pbp/play_by_play_2024.parquet").
+// Pair it with WithOffline so nothing falls through to the network.
+func NewFixtureCache(fsys fs.FS) Cache { return download.NewFixtureCache(fsys) }
+
+// CacheEntry describes one cached asset: its URL, dataset key, season
+// (0 = all seasons), size, ETag, when it was saved and whether it has
+// outlived the cache TTL.
+type CacheEntry = download.Entry
+
+// CacheStats counts cache hits, misses and LRU evictions, alongside the
+// current number of entries and their total size.
+type CacheStats = download.CacheStats
+
+// The cache management functions below act on the cache the options
+// describe (by default the one from NFLREADGO_CACHE / NFLREADGO_CACHE_DIR),
+// e.g. CacheEntries(WithCache(CacheFS, dir, ttl)). With caching off there
+// is nothing to manage and they return zero values. They list and count
+// downloaded files only; ClearCache and PruneCache also drop the decoded
+// rows kept for them (see WithParsedCache).
+
+// CacheEntries lists the cached assets, sorted by dataset, season and URL.
+func CacheEntries(opts ...Option) ([]CacheEntry, error) {
+	cfg := buildConfig(opts)
+	return download.Entries(cfg.CacheBackend())
+}
+
+// ClearCache removes the entries matching pattern and reports how many
+// were removed. Patterns use path.Match syntax against "<dataset>/<season>"
+// (season "all" for all-season files); a bare dataset pattern covers every
+// season:
+//
+//	ClearCache("pbp")          // every play-by-play season
+//	ClearCache("*/2023")       // every dataset's 2023 files
+//	ClearCache("player*/202?") // player datasets, 2020s
+//	ClearCache("*")            // everything
+func ClearCache(pattern string, opts ...Option) (int, error) {
+	cfg := buildConfig(opts)
+	n, err := download.Clear(cfg.CacheBackend(), pattern)
+	if err == nil {
+		_, err = download.Clear(cfg.ParsedBackend(), pattern)
+	}
+	return n, err
+}
+
+// CacheSize reports the total size in bytes of the cached assets.
+func CacheSize(opts ...Option) (int64, error) {
+	cfg := buildConfig(opts)
+	return download.TotalSize(cfg.CacheBackend())
+}
+
+// PruneCache removes entries older than the cache TTL and reports how many
+// were removed.
+func PruneCache(opts ...Option) (int, error) {
+	cfg := buildConfig(opts)
+	n, err := download.Prune(cfg.CacheBackend())
+	if err == nil {
+		_, err = download.Prune(cfg.ParsedBackend())
+	}
+	return n, err
+}
+
+// GetCacheStats reports the cache's hit/miss/eviction counters for this
+// process and its current entry count and size.
+func GetCacheStats(opts ...Option) CacheStats {
+	cfg := buildConfig(opts)
+	st, _ := download.StatsOf(cfg.CacheBackend())
+	return st
+}
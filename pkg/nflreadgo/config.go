@@ -1,114 +1,111 @@
 package nflreadgo
 
 import (
-	"net/http"
-	"os"
-	"strconv"
 	"time"
 
+	"github.com/tyler180/nfl-data-go/internal/config"
 	"github.com/tyler180/nfl-data-go/internal/download"
 )
 
-type CacheMode string
+// Config is the library configuration. It is the same model used by every
+// internal loader; see DefaultConfig for defaults and the precedence rules.
+type Config = config.AppConfig
+
+// Option adjusts the Config for a single call.
+type Option = config.ConfigOption
+
+type CacheMode = config.CacheMode
 
 const (
-	CacheOff CacheMode = "off"
-	CacheFS  CacheMode = "filesystem"
-	CacheMem CacheMode = "memory"
+	CacheOff CacheMode = config.CacheModeOff
+	CacheFS  CacheMode = config.CacheModeFilesystem
+	CacheMem CacheMode = config.CacheModeMemory
 )
 
-type Config struct {
-	CacheMode CacheMode
-	CacheDir  string
-	CacheTTL  time.Duration
-
-	Timeout   time.Duration
-	UserAgent string
-	Verbose   bool
-}
+// Format is a download file format preference.
+type Format = download.Format
 
-type Option func(*Config)
+const (
+	FormatParquet = download.FormatParquet
+	FormatCSV     = download.FormatCSV
+	FormatCSVGzip = download.FormatCSVGzip
+)
 
 func WithCache(mode CacheMode, dir string, ttl time.Duration) Option {
 	return func(c *Config) { c.CacheMode, c.CacheDir, c.CacheTTL = mode, dir, ttl }
 }
-func WithTimeout(d time.Duration) Option { return func(c *Config) { c.Timeout = d } }
-func WithUserAgent(ua string) Option     { return func(c *Config) { c.UserAgent = ua } }
-func WithVerbose(v bool) Option          { return func(c *Config) { c.Verbose = v } }
-
-func DefaultConfig() Config {
-	return Config{
-		CacheMode: CacheFS,
-		CacheDir:  os.ExpandEnv("$HOME/.cache/nflreadgo"),
-		CacheTTL:  24 * time.Hour,
-
-		Timeout:   30 * time.Second,
-		UserAgent: "nflreadgo/0.1 (+github.com/tyler180/nfl-data-go)",
-	}
-}
 
-// buildConfig applies options and environment variables.
-func buildConfig(opts []Option) Config {
-	c := DefaultConfig()
-	for _, o := range opts {
-		o(&c)
-	}
-
-	// --- env overrides (optional, mirrors nflreadpy-style knobs) ---
-	// NFLREADGO_CACHE = off|filesystem|memory
-	if v := os.Getenv("NFLREADGO_CACHE"); v != "" {
-		switch v {
-		case "off":
-			c.CacheMode = CacheOff
-		case "filesystem":
-			c.CacheMode = CacheFS
-		case "memory":
-			c.CacheMode = CacheMem
-		}
-	}
-	if v := os.Getenv("NFLREADGO_CACHE_DIR"); v != "" {
-		c.CacheDir = v
-	}
-	if v := os.Getenv("NFLREADGO_CACHE_TTL"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
-			c.CacheTTL = time.Duration(n) * time.Second
-		}
-	}
-	if v := os.Getenv("NFLREADGO_TIMEOUT"); v != "" {
-		if n, err := strconv.Atoi(v); err == nil && n > 0 {
-			c.Timeout = time.Duration(n) * time.Second
-		}
-	}
-	if v := os.Getenv("NFLREADGO_USER_AGENT"); v != "" {
-		c.UserAgent = v
-	}
-	if v := os.Getenv("NFLREADGO_VERBOSE"); v != "" {
-		c.Verbose = v == "1" || v == "true" || v == "TRUE"
-	}
-	return c
-}
+// WithCacheMaxBytes caps the cache (memory or disk) at n bytes, evicting
+// least recently used entries past it; 0 means unbounded (the default).
+func WithCacheMaxBytes(n int64) Option { return config.WithCacheMaxBytes(n) }
 
-// HTTPClient returns a ready http.Client that respects Config.Timeout.
-// Callers should not mutate its Transport.
-func (c Config) HTTPClient() *http.Client {
-	return &http.Client{
-		Timeout: c.Timeout,
-		// You can inject a custom Transport here (proxy, TLS tweaks, retries, etc.)
-	}
-}
+// WithParsedCache toggles caching of decoded rows (on by default), so warm
+// loads skip parsing. Rows are kept with the filesystem cache, in its rows
+// subdirectory, and tied to the upstream ETag and the model's schema, so
+// they never go stale. Memory and custom caches keep raw files only.
+func WithParsedCache(v bool) Option { return config.WithParsedCache(v) }
+
+func WithTimeout(d time.Duration) Option { return config.WithTimeout(d) }
+func WithUserAgent(ua string) Option     { return config.WithUserAgent(ua) }
+func WithVerbose(v bool) Option          { return config.WithVerbose(v) }
+func WithPreferFormat(f Format) Option   { return config.WithPreferFormat(f) }
+
+// WithWorkers bounds how many seasons a multi-season load fetches in
+// parallel (default 4).
+func WithWorkers(n int) Option { return config.WithWorkers(n) }
 
-// CacheBackend returns a download.Cache implementation wired to the config.
-// Returns nil when caching is disabled.
-func (c Config) CacheBackend() download.Cache {
-	switch c.CacheMode {
-	case CacheFS:
-		if c.CacheDir == "" {
-			return nil
-		}
-		return download.NewFSCache(c.CacheDir, c.CacheTTL)
-	case CacheMem:
-		return download.NewMemCache(c.CacheTTL)
-	default:
-		return nil
-	}
+// WithPartialResults makes multi-season loads keep going when a season
+// fails: the rows that did load are returned together with a
+// *SeasonErrors naming the seasons that didn't.
+func WithPartialResults(v bool) Option { return config.WithPartialResults(v) }
+
+// WithOffline serves every asset from the cache, regardless of TTL, without
+// touching the network. Assets that were never cached fail with
+// ErrNotCached. NFLREADGO_OFFLINE=true does the same.
+func WithOffline(v bool) Option { return config.WithOffline(v) }
+
+// WithStaleIfError serves an expired cache entry when refreshing it fails
+// with a transient error (5xx, rate limiting, network failure).
+// NFLREADGO_STALE_IF_ERROR=true does the same.
+func WithStaleIfError(v bool) Option { return config.WithStaleIfError(v) }
+
+// WithVerifyChecksums checks nflverse-data downloads against the SHA-256
+// digests published in their release manifest. Truncated bodies and
+// HTML/JSON error pages are rejected either way.
+// NFLREADGO_VERIFY_CHECKSUMS=true does the same.
+func WithVerifyChecksums(v bool) Option { return config.WithVerifyChecksums(v) }
+
+// RetryPolicy controls how downloads retry transient failures (408/429/5xx,
+// connection resets, timeouts); Attempt describes one HTTP attempt.
+type (
+	RetryPolicy = download.RetryPolicy
+	Attempt     = download.Attempt
+)
+
+// DefaultRetryPolicy is the policy used unless WithRetry says otherwise:
+// up to 4 attempts, backing off from 500ms to at most 30s with 50% jitter.
+func DefaultRetryPolicy() RetryPolicy { return download.DefaultRetryPolicy() }
+
+// WithRetry sets the retry policy; MaxAttempts <= 1 disables retries.
+// NFLREADGO_RETRY_MAX_ATTEMPTS overrides the attempt count.
+func WithRetry(p RetryPolicy) Option { return config.WithRetry(p) }
+
+// WithRetryHook calls fn after every HTTP attempt, e.g. to log retries.
+// fn may be called concurrently.
+func WithRetryHook(fn func(Attempt)) Option { return config.WithRetryHook(fn) }
+
+// WithClock overrides the clock used by GetCurrentSeason/GetCurrentWeek and
+// the Weeks/bool selectors (useful for tests and backfills).
+func WithClock(now func() time.Time) Option { return config.WithClock(now) }
+
+// DefaultConfig returns the built-in defaults (filesystem cache in the user
+// cache directory, e.g. $HOME/.cache/nflreadgo, 24h TTL, Parquet preferred,
+// 30s timeout), before env or options are applied.
+func DefaultConfig() Config { return *config.DefaultAppConfig() }
+
+// buildConfig resolves the effective config for one call. Precedence,
+// highest first: opts > environment (NFLREADGO_* or NFLREADPY_*) > .env >
+// defaults.
+func buildConfig(opts []Option) Config {
+	return *config.Resolve(opts...)
 }
@@ -0,0 +1,34 @@
+package nflreadgo
+
+import (
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
+)
+
+// Error categories; test with errors.Is.
+var (
+	ErrNotFound            = errs.ErrNotFound            // the asset does not exist upstream (404/410)
+	ErrRateLimited         = errs.ErrRateLimited         // GitHub throttled the request (429/403)
+	ErrUpstreamUnavailable = errs.ErrUpstreamUnavailable // 5xx or network failure after retries
+	ErrParse               = errs.ErrParse               // malformed CSV/Parquet/compressed data
+	ErrSchemaMismatch      = errs.ErrSchemaMismatch      // the file has none of the expected columns
+	ErrSeasonUnavailable   = errs.ErrSeasonUnavailable   // a selector names a season the dataset lacks
+	ErrNotCached           = errs.ErrNotCached           // offline and the asset was never cached
+	ErrIntegrity           = errs.ErrIntegrity           // truncated body, checksum mismatch or an error page
+)
+
+// Typed errors; extract with errors.As.
+type (
+	// Error wraps every loader failure with its dataset key and URL.
+	Error = errs.Error
+	// HTTPError is a non-2xx response (status code, URL, body preview).
+	HTTPError = download.HTTPError
+	// ParseError locates malformed input by URL and row.
+	ParseError = errs.ParseError
+	// SchemaError lists the expected and actual columns of a mismatched file.
+	SchemaError = errs.SchemaError
+	// IntegrityError names the failed check (length, checksum, content).
+	IntegrityError = errs.IntegrityError
+	// SeasonErrors lists the seasons that failed in a WithPartialResults load.
+	SeasonErrors = errs.SeasonErrors
+)
@@ -0,0 +1,18 @@
+package nflreadgo
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/pbp"
+)
+
+// PlayByPlay is a single play from the nflverse play-by-play dataset.
+type PlayByPlay = pbp.PlayByPlay
+
+// LoadPBP loads play-by-play for the seasons in sel, which accepts the same
+// selector shapes as LoadSnapCounts (int, []int, Seasons, Weeks,
+// []SeasonWeeks, bool). Each season is a separate upstream file.
+func LoadPBP(ctx context.Context, sel any, opts ...Option) ([]PlayByPlay, error) {
+	return loadSeasons(ctx, datasets.PlayByPlay, sel, opts, pbp.LoadSeason, func(p PlayByPlay) (int, int) { return p.Season, p.Week })
+}
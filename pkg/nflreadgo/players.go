@@ -0,0 +1,31 @@
+package nflreadgo
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/ffplayerids"
+	"github.com/tyler180/nfl-data-go/internal/datasets/players"
+)
+
+// Player is one row of the nflverse players table.
+type Player = players.Player
+
+// FFPlayerID is one row of the DynastyProcess fantasy player ID map.
+type FFPlayerID = ffplayerids.FFPlayerID
+
+// LoadPlayers loads the players table. Upstream publishes a single snapshot
+// that is not season-scoped, so there is no selector.
+func LoadPlayers(ctx context.Context, opts ...Option) ([]Player, error) {
+	cfg := buildConfig(opts)
+	ctx = datasets.WithConfig(ctx, &cfg)
+	return players.Load(ctx)
+}
+
+// LoadFFPlayerIDs loads the fantasy-platform player ID crosswalk
+// (ff_playerids). Like LoadPlayers it is a single, unscoped snapshot.
+func LoadFFPlayerIDs(ctx context.Context, opts ...Option) ([]FFPlayerID, error) {
+	cfg := buildConfig(opts)
+	ctx = datasets.WithConfig(ctx, &cfg)
+	return ffplayerids.Load(ctx)
+}
@@ -0,0 +1,137 @@
+package nflreadgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/source"
+)
+
+// CacheStatus says how an asset was served: from a fresh cache entry,
+// revalidated with the server (304), downloaded, or served stale.
+type CacheStatus = download.CacheStatus
+
+const (
+	CacheDownloaded  = download.CacheDownloaded
+	CacheFresh       = download.CacheFresh
+	CacheRevalidated = download.CacheRevalidated
+	CacheStale       = download.CacheStale
+)
+
+// PrefetchResult reports how Prefetch brought one asset into the cache.
+type PrefetchResult struct {
+	Dataset Dataset
+	Season  int    // requested season; 0 for an all-seasons file
+	URL     string // the asset used (after format/season fallback), or the one that failed
+	Status  CacheStatus
+	Bytes   int64
+	Err     error // nil unless this asset failed
+}
+
+// Prefetch warms the cache for a scheduled job: it brings the files the
+// loaders would read for datasets ds and the seasons in sel (the loaders'
+// selector shapes) into the configured cache, up to WithWorkers at a time,
+// without parsing them. Datasets published as one all-seasons file
+// (players, schedules) are fetched once whatever sel says.
+//
+// Every asset is attempted. The results come back in request order with
+// each one's CacheStatus; the error joins the failures. Prefetch fails
+// outright if a dataset is unknown or the cache doesn't outlive the process
+// (caching off, or a memory cache).
+func Prefetch(ctx context.Context, ds []Dataset, sel any, opts ...Option) ([]PrefetchResult, error) {
+	cfg := buildConfig(opts)
+	if !download.Persistent(cfg.CacheBackend()) {
+		return nil, errors.New("nflreadgo: prefetch needs a cache that outlives the process, such as the filesystem cache (see WithCache)")
+	}
+	ctx = datasets.WithConfig(ctx, &cfg)
+	cur := seasonAt(cfg.Now())
+
+	var jobs []PrefetchResult
+	for _, d := range ds {
+		js, err := prefetchJobs(d, sel, cur)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, js...)
+	}
+
+	// Fan out over job indices; failures are kept per result instead of
+	// cancelling the rest.
+	idx := make([]int, len(jobs))
+	for i := range idx {
+		idx[i] = i
+	}
+	out, _ := datasets.LoadSeasonsConcurrently(ctx, idx, datasets.MultiOptions{Workers: cfg.Workers}, func(ctx context.Context, i int) ([]PrefetchResult, error) {
+		r := jobs[i]
+		var (
+			a   datasets.Asset
+			err error
+		)
+		if r.URL != "" {
+			a, r.Bytes, err = datasets.PrefetchURL(ctx, string(r.Dataset), r.Season, r.URL)
+		} else {
+			src, _ := datasets.SourceFor(r.Dataset)
+			a, r.Bytes, err = datasets.Prefetch(ctx, src, r.Season)
+		}
+		var e *Error
+		switch {
+		case a.URL != "":
+			r.URL = a.URL
+		case errors.As(err, &e) && e.URL != "":
+			r.URL = e.URL // the last asset tried
+		}
+		r.Status, r.Err = a.Status, err
+		return []PrefetchResult{r}, nil
+	})
+
+	var failed []error
+	for _, r := range out {
+		if r.Err != nil {
+			failed = append(failed, r.Err)
+		}
+	}
+	return out, errors.Join(failed...)
+}
+
+// prefetchJobs expands one dataset into the assets to fetch. Snap counts
+// use the NFLREADGO_SNAP_URL/NFLREADGO_SNAP_PATTERN override when one is set,
+// like LoadSnapCounts.
+func prefetchJobs(d Dataset, sel any, cur int) ([]PrefetchResult, error) {
+	if _, ok := source.SnapCountOverrideURLs(nil); ok && d == DatasetSnapCounts {
+		seasons, err := resolveSeasons(d, sel, cur)
+		if err != nil || len(seasons) == 0 {
+			return nil, err
+		}
+		urls, _ := source.SnapCountOverrideURLs(seasons)
+		out := make([]PrefetchResult, len(urls))
+		for i, u := range urls {
+			out[i] = PrefetchResult{Dataset: d, URL: u}
+			if len(urls) == len(seasons) {
+				out[i].Season = seasons[i]
+			}
+		}
+		return out, nil
+	}
+
+	if _, ok := datasets.SourceFor(d); !ok {
+		return nil, fmt.Errorf("nflreadgo: prefetch: unknown dataset %q", d)
+	}
+	if _, err := datasets.AvailableSeasons(d, cur); err != nil {
+		return []PrefetchResult{{Dataset: d}}, nil // not season-scoped
+	}
+	seasons, err := resolveSeasons(d, sel, cur)
+	if err != nil {
+		return nil, err
+	}
+	if d == DatasetSchedules {
+		return []PrefetchResult{{Dataset: d}}, nil // one all-seasons file
+	}
+	out := make([]PrefetchResult, len(seasons))
+	for i, yr := range seasons {
+		out[i] = PrefetchResult{Dataset: d, Season: yr}
+	}
+	return out, nil
+}
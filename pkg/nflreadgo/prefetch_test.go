@@ -0,0 +1,37 @@
+package nflreadgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestPrefetch_NeedsPersistentCache(t *testing.T) {
+	ctx := context.Background()
+	for name, opt := range map[string]Option{
+		"off":            WithCache(CacheOff, "", 0),
+		"memory":         WithCache(CacheMem, "", time.Hour),
+		"layered memory": WithCacheBackend(NewLayeredCache(NewMemCache(time.Hour), NewMemCache(0))),
+	} {
+		if _, err := Prefetch(ctx, []Dataset{"players"}, nil, opt); err == nil {
+			t.Errorf("Prefetch with the %s cache: err = nil, want a refusal", name)
+		}
+	}
+}
+
+func TestPrefetch_ReportsFailedURL(t *testing.T) {
+	t.Setenv("NFLREADGO_SNAP_PATTERN", "https://mirror.example/snaps_%d.csv")
+	opts := []Option{WithCacheBackend(NewFixtureCache(fstest.MapFS{})), WithOffline(true)}
+	res, err := Prefetch(context.Background(), []Dataset{DatasetSnapCounts, "players"}, Seasons{2024}, opts...)
+	if !errors.Is(err, ErrNotCached) || len(res) != 2 {
+		t.Fatalf("Prefetch = %d results, %v; want two ErrNotCached failures", len(res), err)
+	}
+	if res[0].URL != "https://mirror.example/snaps_2024.csv" {
+		t.Errorf("override failure: URL = %q, want the mirror URL", res[0].URL)
+	}
+	if res[1].URL == "" {
+		t.Error("resolved failure: URL is empty, want the asset tried")
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/snapcounts"
 	"github.com/tyler180/nfl-data-go/internal/download"
+	"github.com/tyler180/nfl-data-go/internal/errs"
 	"github.com/tyler180/nfl-data-go/internal/source"
 )
 
@@ -14,30 +17,38 @@ import (
 // MIME type for each blob (derived via http.DetectContentType).
 func LoadSnapCountsRaw(ctx context.Context, sel any, opts ...Option) (blobs [][]byte, mimes []string, err error) {
 	cfg := buildConfig(opts)
-	dl := download.New(
-		download.WithHTTPClient(cfg.HTTPClient()),
-		download.WithCache(cfg.CacheBackend()),
-		download.WithUserAgent(cfg.UserAgent),
-	)
+	ctx = datasets.WithConfig(ctx, &cfg)
+	dl := newDownloader(cfg)
 
-	seasons := expandSeasons(sel)
+	cur := seasonAt(cfg.Now())
+	seasons, err := resolveSeasons(datasets.SnapCounts, sel, cur)
+	if err != nil {
+		return nil, nil, err
+	}
 	if len(seasons) == 0 {
-		seasons = []int{GetCurrentSeason()}
+		seasons = []int{cur}
 	}
 
-	urls := source.NFLVerseSnapCountURLs(seasons)
-	blobs = make([][]byte, 0, len(urls))
-	mimes = make([]string, 0, len(urls))
+	urls, override := source.SnapCountOverrideURLs(seasons)
+	n := len(seasons)
+	if override {
+		n = len(urls)
+	}
+	blobs = make([][]byte, 0, n)
+	mimes = make([]string, 0, n)
 
-	for _, u := range urls {
-		rc, _, e := dl.Fetch(ctx, u)
-		if e != nil {
-			return nil, nil, e
+	for i := range n {
+		var b []byte
+		switch {
+		case !override:
+			b, _, err = snapcounts.LoadSeasonRaw(ctx, seasons[i])
+		case len(urls) == len(seasons):
+			b, err = fetchRaw(ctx, dl, seasons[i], urls[i])
+		default:
+			b, err = fetchRaw(ctx, dl, 0, urls[i]) // one combined override file
 		}
-		b, e := io.ReadAll(rc)
-		rc.Close()
-		if e != nil {
-			return nil, nil, e
+		if err != nil {
+			return nil, nil, err
 		}
 		mt := "application/octet-stream"
 		if len(b) > 0 {
@@ -52,3 +63,18 @@ func LoadSnapCountsRaw(ctx context.Context, sel any, opts ...Option) (blobs [][]
 	}
 	return blobs, mimes, nil
 }
+
+// fetchRaw reads the snap count override url whole through dl,
+// recorded in the cache index under season.
+func fetchRaw(ctx context.Context, dl *download.Client, season int, u string) ([]byte, error) {
+	rc, _, err := dl.Fetch(download.WithAsset(ctx, string(datasets.SnapCounts), season), u)
+	if err != nil {
+		return nil, errs.Wrap(string(datasets.SnapCounts), u, err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, errs.Wrap(string(datasets.SnapCounts), u, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,42 @@
+package nflreadgo
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/depthcharts"
+	"github.com/tyler180/nfl-data-go/internal/datasets/injuries"
+	"github.com/tyler180/nfl-data-go/internal/datasets/rosters"
+)
+
+// Roster is one row of the season or weekly roster tables.
+type Roster = rosters.Roster
+
+// DepthChart is one depth chart entry.
+type DepthChart = depthcharts.DepthChart
+
+// Injury is one injury report entry.
+type Injury = injuries.Injury
+
+// LoadRosters loads season-level rosters for the seasons in sel
+// (int, []int, Seasons, Weeks, []SeasonWeeks, bool).
+func LoadRosters(ctx context.Context, sel any, opts ...Option) ([]Roster, error) {
+	return loadSeasons(ctx, datasets.Rosters, sel, opts, rosters.LoadSeason, rosterAt)
+}
+
+// LoadRostersWeekly loads week-by-week rosters for the seasons in sel.
+func LoadRostersWeekly(ctx context.Context, sel any, opts ...Option) ([]Roster, error) {
+	return loadSeasons(ctx, datasets.RostersWeekly, sel, opts, rosters.LoadWeeklySeason, rosterAt)
+}
+
+// LoadDepthCharts loads depth charts for the seasons in sel.
+func LoadDepthCharts(ctx context.Context, sel any, opts ...Option) ([]DepthChart, error) {
+	return loadSeasons(ctx, datasets.DepthCharts, sel, opts, depthcharts.LoadSeason, func(d DepthChart) (int, int) { return d.Season, d.Week })
+}
+
+// LoadInjuries loads injury reports for the seasons in sel.
+func LoadInjuries(ctx context.Context, sel any, opts ...Option) ([]Injury, error) {
+	return loadSeasons(ctx, datasets.Injuries, sel, opts, injuries.LoadSeason, func(i Injury) (int, int) { return i.Season, i.Week })
+}
+
+func rosterAt(r Roster) (int, int) { return r.Season, r.Week }
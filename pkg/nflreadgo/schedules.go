@@ -0,0 +1,29 @@
+package nflreadgo
+
+import (
+	"context"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/schedules"
+)
+
+// Game is a single scheduled or played game from the nflverse schedules.
+type Game = schedules.Game
+
+// LoadSchedules loads games for the seasons in sel (same selector shapes as
+// LoadSnapCounts). Upstream publishes one all-seasons file, so it is fetched
+// once and filtered locally; bool(true) returns every season.
+func LoadSchedules(ctx context.Context, sel any, opts ...Option) ([]Game, error) {
+	cfg := buildConfig(opts)
+	ctx = datasets.WithConfig(ctx, &cfg)
+
+	cur := seasonAt(cfg.Now())
+	if _, err := resolveSeasons(datasets.Schedules, sel, cur); err != nil {
+		return nil, err
+	}
+	games, err := schedules.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterBySelection(games, sel, cur, func(g Game) (int, int) { return g.Season, g.Week }), nil
+}
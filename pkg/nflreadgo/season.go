@@ -0,0 +1,79 @@
+package nflreadgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/schedules"
+)
+
+// Dataset identifies a dataset for season-availability queries and Prefetch.
+type Dataset = datasets.Key
+
+const (
+	DatasetPBP           Dataset = datasets.PlayByPlay
+	DatasetPlayerStats   Dataset = datasets.PlayerStatsWeekly
+	DatasetTeamStats     Dataset = datasets.TeamStatsWeekly
+	DatasetSchedules     Dataset = datasets.Schedules
+	DatasetRosters       Dataset = datasets.Rosters
+	DatasetRostersWeekly Dataset = datasets.RostersWeekly
+	DatasetSnapCounts    Dataset = datasets.SnapCounts
+	DatasetInjuries      Dataset = datasets.Injuries
+	DatasetDepthCharts   Dataset = datasets.DepthCharts
+	DatasetPlayers       Dataset = datasets.Players // not season-scoped
+)
+
+// AvailableSeasons lists the seasons ds publishes. By default this is the
+// dataset's first season through the current one; with refresh, the list is
+// re-read from the dataset's release asset listing and used by later "all
+// seasons" selectors and range checks.
+func AvailableSeasons(ctx context.Context, ds Dataset, refresh bool, opts ...Option) ([]int, error) {
+	cfg := buildConfig(opts)
+	if refresh {
+		return datasets.RefreshSeasons(datasets.WithConfig(ctx, &cfg), ds)
+	}
+	return datasets.AvailableSeasons(ds, seasonAt(cfg.Now()))
+}
+
+// GetCurrentSeason returns the NFL season in progress at the configured
+// clock (WithClock; defaults to time.Now). Like nflreadr, the season rolls
+// over on the Thursday after Labor Day, so January/February playoff dates
+// belong to the previous calendar year's season.
+func GetCurrentSeason(opts ...Option) int {
+	return seasonAt(buildConfig(opts).Now())
+}
+
+// GetCurrentWeek returns the current (or next upcoming) week of the current
+// season, derived from the schedules dataset: the week of the earliest game on
+// or after today. Before the season starts it returns 1; after the last game
+// it returns the season's final week.
+func GetCurrentWeek(ctx context.Context, opts ...Option) (int, error) {
+	cfg := buildConfig(opts)
+	now := cfg.Now()
+	season := seasonAt(now)
+
+	games, err := LoadSchedules(ctx, season, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return schedules.CurrentWeek(games, season, now), nil
+}
+
+// seasonAt maps a date to the season it falls in.
+func seasonAt(now time.Time) int {
+	y := now.Year()
+	if now.Before(seasonStart(y)) {
+		return y - 1
+	}
+	return y
+}
+
+// seasonStart returns the Thursday after Labor Day (first Monday of September).
+func seasonStart(year int) time.Time {
+	d := time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for d.Weekday() != time.Monday {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d.AddDate(0, 0, 3)
+}
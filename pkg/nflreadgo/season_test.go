@@ -0,0 +1,32 @@
+package nflreadgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetCurrentSeason_Rollover(t *testing.T) {
+	cases := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2025, time.February, 9, 18, 0, 0, 0, time.UTC), 2024},  // Super Bowl LIX
+		{time.Date(2024, time.September, 4, 12, 0, 0, 0, time.UTC), 2023}, // day before kickoff
+		{time.Date(2024, time.September, 5, 0, 0, 0, 0, time.UTC), 2024},  // Thursday after Labor Day
+		{time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), 2024},
+	}
+	for _, c := range cases {
+		got := GetCurrentSeason(WithClock(func() time.Time { return c.now }))
+		if got != c.want {
+			t.Errorf("GetCurrentSeason(%s) = %d, want %d", c.now.Format("2006-01-02"), got, c.want)
+		}
+	}
+}
+
+func fixedClock(day string) func() time.Time {
+	d, err := time.Parse("2006-01-02", day)
+	if err != nil {
+		panic(err)
+	}
+	return func() time.Time { return d }
+}
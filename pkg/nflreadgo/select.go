@@ -1,15 +1,14 @@
 package nflreadgo
 
-import "github.com/tyler180/nfl-data-go/internal/schema"
-
-// filterBySelection narrows []SnapCount according to 'sel'.
+// filterBySelection narrows rows according to 'sel'; at returns a row's
+// season and week.
 // Supported selectors (same shapes accepted by your loaders):
 //   - Seasons{...} or []int (treated as seasons): filter by Season only
 //   - []SeasonWeeks: filter by Season AND listed Weeks (empty Weeks = all)
 //   - int (single season)
-//   - Weeks (applies to current season)
+//   - Weeks (applies to cur, the current season)
 //   - bool(true) => no filtering (“all”)
-func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
+func filterBySelection[T any](rows []T, sel any, cur int, at func(T) (season, week int)) []T {
 	if len(rows) == 0 || sel == nil {
 		return rows
 	}
@@ -22,7 +21,8 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 		}
 		out := rows[:0]
 		for _, r := range rows {
-			if _, ok := seasonSet[r.Season]; ok {
+			season, _ := at(r)
+			if _, ok := seasonSet[season]; ok {
 				out = append(out, r)
 			}
 		}
@@ -35,7 +35,8 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 		}
 		out := rows[:0]
 		for _, r := range rows {
-			if _, ok := seasonSet[r.Season]; ok {
+			season, _ := at(r)
+			if _, ok := seasonSet[season]; ok {
 				out = append(out, r)
 			}
 		}
@@ -45,7 +46,7 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 		season := v
 		out := rows[:0]
 		for _, r := range rows {
-			if r.Season == season {
+			if s, _ := at(r); s == season {
 				out = append(out, r)
 			}
 		}
@@ -53,19 +54,19 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 
 	case Weeks:
 		// Interpret bare Weeks as "weeks from current season".
-		cur := GetCurrentSeason()
 		weekSet := make(map[int]struct{}, len(v))
 		for _, w := range v {
 			weekSet[w] = struct{}{}
 		}
 		out := rows[:0]
 		for _, r := range rows {
-			if r.Season == cur {
+			season, week := at(r)
+			if season == cur {
 				if len(weekSet) == 0 {
 					out = append(out, r)
 					continue
 				}
-				if _, ok := weekSet[r.Week]; ok {
+				if _, ok := weekSet[week]; ok {
 					out = append(out, r)
 				}
 			}
@@ -92,7 +93,8 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 
 		out := rows[:0]
 		for _, r := range rows {
-			ws, ok := bySeason[r.Season]
+			season, week := at(r)
+			ws, ok := bySeason[season]
 			if !ok {
 				continue
 			}
@@ -100,7 +102,7 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 				out = append(out, r)
 				continue
 			}
-			if _, ok := ws[r.Week]; ok {
+			if _, ok := ws[week]; ok {
 				out = append(out, r)
 			}
 		}
@@ -115,3 +117,22 @@ func filterBySelection(rows []schema.SnapCount, sel any) []schema.SnapCount {
 		return rows
 	}
 }
+
+// seasonsOnly drops week filtering from sel for season-total stats, where
+// every row has Week == 0. Weeks still means "the current season".
+func seasonsOnly(sel any, level SummaryLevel) any {
+	if level == SummaryWeek || level == "" {
+		return sel
+	}
+	switch v := sel.(type) {
+	case Weeks:
+		return Weeks{} // empty Weeks = every row of the current season
+	case []SeasonWeeks:
+		out := make([]SeasonWeeks, len(v))
+		for i, sw := range v {
+			out[i] = SeasonWeeks{Season: sw.Season}
+		}
+		return out
+	}
+	return sel
+}
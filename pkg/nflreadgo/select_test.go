@@ -0,0 +1,96 @@
+package nflreadgo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+)
+
+type sw struct{ season, week int }
+
+func TestLoadSeasons_Selectors(t *testing.T) {
+	load := func(_ context.Context, season int) ([]sw, error) {
+		return []sw{{season, 1}, {season, 2}, {season, 3}}, nil
+	}
+	at := func(r sw) (int, int) { return r.season, r.week }
+	clock := WithClock(fixedClock("2024-10-01"))
+
+	cases := []struct {
+		name string
+		sel  any
+		want []sw
+	}{
+		{"int", 2023, []sw{{2023, 1}, {2023, 2}, {2023, 3}}},
+		{"weeks", Weeks{2}, []sw{{2024, 2}}},
+		{"season weeks", []SeasonWeeks{{Season: 2022, Weeks: []int{3}}, {Season: 2023}}, []sw{{2022, 3}, {2023, 1}, {2023, 2}, {2023, 3}}},
+		{"totals drop weeks", seasonsOnly([]SeasonWeeks{{Season: 2022, Weeks: []int{3}}}, SummaryReg), []sw{{2022, 1}, {2022, 2}, {2022, 3}}},
+	}
+	for _, c := range cases {
+		got, err := loadSeasons(context.Background(), datasets.PlayByPlay, c.sel, []Option{clock}, load, at)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestLoadSeasons_Availability(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		loaded []int
+	)
+	load := func(_ context.Context, season int) ([]sw, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		loaded = append(loaded, season)
+		return nil, nil
+	}
+	at := func(r sw) (int, int) { return r.season, r.week }
+	opts := []Option{WithClock(fixedClock("2025-01-15"))} // 2024 season playoffs
+
+	if _, err := loadSeasons(context.Background(), datasets.SnapCounts, true, opts, load, at); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(loaded) // seasons load in parallel
+	if len(loaded) != 13 || loaded[0] != 2012 || loaded[12] != 2024 {
+		t.Fatalf("all seasons = %v, want 2012..2024", loaded)
+	}
+
+	loaded = nil
+	_, err := loadSeasons(context.Background(), datasets.SnapCounts, Seasons{2011, 2012}, opts, load, at)
+	if !errors.Is(err, datasets.ErrSeasonUnavailable) || loaded != nil {
+		t.Fatalf("err = %v (loaded %v), want ErrSeasonUnavailable before any fetch", err, loaded)
+	}
+}
+
+func TestLoadSeasons_Partial(t *testing.T) {
+	load := func(_ context.Context, season int) ([]sw, error) {
+		if season == 2023 {
+			return nil, ErrNotFound
+		}
+		return []sw{{season, 1}}, nil
+	}
+	at := func(r sw) (int, int) { return r.season, r.week }
+	clock := WithClock(fixedClock("2024-10-01"))
+	sel := Seasons{2022, 2023, 2024}
+
+	if got, err := loadSeasons(context.Background(), datasets.PlayByPlay, sel, []Option{clock}, load, at); got != nil || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("fail-fast: got %v, %v", got, err)
+	}
+
+	got, err := loadSeasons(context.Background(), datasets.PlayByPlay, sel, []Option{clock, WithPartialResults(true)}, load, at)
+	var se *SeasonErrors
+	if !errors.As(err, &se) || !slices.Equal(se.Seasons, []int{2023}) {
+		t.Fatalf("partial: err = %v, want SeasonErrors for 2023", err)
+	}
+	if !reflect.DeepEqual(got, []sw{{2022, 1}, {2024, 1}}) {
+		t.Fatalf("partial: rows = %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package nflreadgo
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+const snapCSV = "game_id,season,week,pfr_player_id,team,offense_snaps,player_snaps\n" +
+	"2024_01_BAL_KC,2024,1,MahoPa00,kc,70,70\n" +
+	"2024_02_CIN_KC,2024,2,MahoPa00,kc,60,30\n"
+
+func TestLoadSnapCounts_ResolvesLikeOtherDatasets(t *testing.T) {
+	fsys := fstest.MapFS{
+		"github.com/nflverse/nflverse-data/releases/download/snap_counts/snap_counts_2024.csv": {Data: []byte(snapCSV)},
+	}
+	rows, err := LoadSnapCounts(context.Background(), []SeasonWeeks{{Season: 2024, Weeks: []int{2}}},
+		WithCacheBackend(NewFixtureCache(fsys)), WithOffline(true), WithPreferFormat(FormatCSV))
+	if err != nil {
+		t.Fatalf("LoadSnapCounts: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Week != 2 || rows[0].Team != "KC" || rows[0].SnapPct != 50 {
+		t.Fatalf("rows = %+v; want week 2 for KC at 50%%", rows)
+	}
+
+	blobs, _, err := LoadSnapCountsRaw(context.Background(), Seasons{2024},
+		WithCacheBackend(NewFixtureCache(fsys)), WithOffline(true), WithPreferFormat(FormatCSV))
+	if err != nil || len(blobs) != 1 || string(blobs[0]) != snapCSV {
+		t.Fatalf("LoadSnapCountsRaw = %q, %v; want the cached CSV", blobs, err)
+	}
+}
+
+func TestLoadSnapCounts_PatternOverride(t *testing.T) {
+	t.Setenv("NFLREADGO_SNAP_PATTERN", "https://mirror.example/snaps_%d.csv")
+	fsys := fstest.MapFS{"mirror.example/snaps_2024.csv": {Data: []byte(snapCSV)}}
+	rows, err := LoadSnapCounts(context.Background(), Seasons{2024},
+		WithCacheBackend(NewFixtureCache(fsys)), WithOffline(true))
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("LoadSnapCounts = %d rows, %v; want both rows from the mirror", len(rows), err)
+	}
+}
@@ -0,0 +1,75 @@
+package nflreadgo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tyler180/nfl-data-go/internal/datasets"
+	"github.com/tyler180/nfl-data-go/internal/datasets/playerstats"
+	"github.com/tyler180/nfl-data-go/internal/datasets/teamstats"
+)
+
+// PlayerStat is one row of the player stats tables.
+type PlayerStat = playerstats.PlayerStat
+
+// TeamStat is one row of the team stats tables.
+type TeamStat = teamstats.TeamStat
+
+// SummaryLevel selects the aggregation of the stats tables (nflreadr's
+// summary_level).
+type SummaryLevel string
+
+const (
+	SummaryWeek    SummaryLevel = "week"     // one row per week
+	SummaryReg     SummaryLevel = "reg"      // regular-season totals
+	SummaryPost    SummaryLevel = "post"     // postseason totals
+	SummaryRegPost SummaryLevel = "reg+post" // full-season totals
+)
+
+// LoadPlayerStats loads week-level player stats for the seasons in sel
+// (int, []int, Seasons, Weeks, []SeasonWeeks, bool).
+func LoadPlayerStats(ctx context.Context, sel any, opts ...Option) ([]PlayerStat, error) {
+	return LoadPlayerStatsSummary(ctx, sel, SummaryWeek, opts...)
+}
+
+// LoadPlayerStatsSummary is LoadPlayerStats at the given summary level.
+// Season totals have Week == 0, so week selectors only apply to SummaryWeek.
+func LoadPlayerStatsSummary(ctx context.Context, sel any, level SummaryLevel, opts ...Option) ([]PlayerStat, error) {
+	var load func(context.Context, int) ([]PlayerStat, error)
+	switch level {
+	case SummaryWeek, "":
+		load = playerstats.LoadForSeason
+	case SummaryReg:
+		load = playerstats.LoadSeasonRegForSeason
+	case SummaryPost:
+		load = playerstats.LoadSeasonPostForSeason
+	case SummaryRegPost:
+		load = playerstats.LoadSeasonRegPostForSeason
+	default:
+		return nil, fmt.Errorf("nflreadgo: unknown summary level %q", level)
+	}
+	return loadSeasons(ctx, datasets.PlayerStatsWeekly, seasonsOnly(sel, level), opts, load, func(p PlayerStat) (int, int) { return p.Season, p.Week })
+}
+
+// LoadTeamStats loads week-level team stats for the seasons in sel.
+func LoadTeamStats(ctx context.Context, sel any, opts ...Option) ([]TeamStat, error) {
+	return LoadTeamStatsSummary(ctx, sel, SummaryWeek, opts...)
+}
+
+// LoadTeamStatsSummary is LoadTeamStats at the given summary level.
+func LoadTeamStatsSummary(ctx context.Context, sel any, level SummaryLevel, opts ...Option) ([]TeamStat, error) {
+	var load func(context.Context, int) ([]TeamStat, error)
+	switch level {
+	case SummaryWeek, "":
+		load = teamstats.LoadForSeason
+	case SummaryReg:
+		load = teamstats.LoadSeasonRegForSeason
+	case SummaryPost:
+		load = teamstats.LoadSeasonPostForSeason
+	case SummaryRegPost:
+		load = teamstats.LoadSeasonRegPostForSeason
+	default:
+		return nil, fmt.Errorf("nflreadgo: unknown summary level %q", level)
+	}
+	return loadSeasons(ctx, datasets.TeamStatsWeekly, seasonsOnly(sel, level), opts, load, func(t TeamStat) (int, int) { return t.Season, t.Week })
+}